@@ -5,18 +5,31 @@ package app
 
 import (
 	"context"
+	pbALS "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	pbRLS "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/cmd/carisma-control-plane/app/xds"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/accesslog"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/metrics"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	pbFault "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/fault/v1"
 	pbNode "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/node/v1"
+	pbReplication "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/replication/v1"
 	pbService "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	pbTraffic "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/traffic/v1"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/ratelimit"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"net"
+	"net/http"
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
 )
 
 func Run() {
@@ -26,6 +39,9 @@ func Run() {
 	logging.DefaultLogger.Info().Msg("Starting CARISMA Control Plane 🚀")
 
 	cfg, err := config.New()
+	if err == nil {
+		err = cfg.ValidateControlTLS()
+	}
 	if err != nil {
 		logging.DefaultLogger.Error().Err(err).Msg("")
 
@@ -36,16 +52,166 @@ func Run() {
 		logging.EnableDebugLogs()
 	}
 
-	grpcServer := grpc.NewServer([]grpc.ServerOption{grpc.MaxConcurrentStreams(1000000)}...)
+	store, err := registry.NewStore(cfg.RegistryFilePath)
+	if err != nil {
+		logging.DefaultLogger.Error().Err(err).Msg("")
+
+		return
+	}
+
+	var ca *pki.CA
+	if cfg.EnableHighAvailability && cfg.EnableStandbyMode && (cfg.EnableMTLS || cfg.EnableControlTLS) {
+		// Both control planes issue the certificates of the nodes with the certificate authority created by the primary
+		// control plane, so that the nodes keep trusting the control plane and each other after a takeover.
+		ca, err = pki.LoadCA(cfg.CACertFilePath, cfg.CAKeyFilePath)
+		if err != nil {
+			logging.DefaultLogger.Error().Err(err).
+				Msg("standby control plane requires the certificate authority of the primary control plane")
+
+			return
+		}
+	} else if cfg.EnableMTLS || cfg.EnableControlTLS {
+		ca, err = pki.LoadOrCreateCA(cfg.CACertFilePath, cfg.CAKeyFilePath)
+		if err != nil {
+			logging.DefaultLogger.Error().Err(err).Msg("")
+
+			return
+		}
+	}
+
+	// a control plane in high availability mode only serves the nodes once it leads
+	if cfg.EnableHighAvailability {
+		if err := awaitLeadership(ctx, cfg, store, ca); err != nil {
+			logging.DefaultLogger.Error().Err(err).Msg("")
+
+			return
+		}
+	}
+
+	// nodes only receive client certificates if the control channel is protected by TLS
+	var nodeCA *pki.CA
+	if cfg.EnableControlTLS {
+		nodeCA = ca
+	}
+
+	auth, err := registry.NewAuthenticator(cfg.AdmissionToken, cfg.SessionKeyFilePath, nodeCA,
+		time.Duration(cfg.NodeCertTTL)*config.TimeUnit)
+	if err != nil {
+		logging.DefaultLogger.Error().Err(err).Msg("")
+
+		return
+	}
+
+	if !auth.AdmissionRestricted() {
+		logging.DefaultLogger.Warn().Msg("Admission of nodes is not restricted, configure an admission token or enable control TLS")
+	}
+
+	serverOptions := []grpc.ServerOption{
+		grpc.MaxConcurrentStreams(1000000),
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor()),
+	}
+
+	if cfg.EnableControlTLS {
+		tlsConfig, err := ca.ServerTLSConfig(
+			"carisma-control-plane",
+			[]string{cfg.CentralNodeHostname, cfg.NodeHostname, "localhost"},
+			time.Duration(cfg.NodeCertTTL)*config.TimeUnit,
+		)
+		if err != nil {
+			logging.DefaultLogger.Error().Err(err).Msg("")
+
+			return
+		}
+
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(serverOptions...)
+
+	var xdsCA *pki.CA
+	if cfg.EnableMTLS {
+		xdsCA = ca
+	} else {
+		logging.DefaultLogger.Warn().Msg("Traffic between the nodes is not authenticated, enable mutual TLS")
+	}
+
+	// Envoy does not support DTLS, the ingress listeners of UDP service ports are only restricted to the node addresses
+	logging.DefaultLogger.Warn().Msg("UDP traffic between the nodes is neither authenticated nor encrypted")
+
+	xdsServer := xds.NewServer(xdsCA)
+
+	// rebuild the snapshots from the persisted registry before any Envoy (re)connects
+	err = xdsServer.Restore(ctx, cfg, store.Nodes(), store.Services(), store.Weights())
+	logging.LogErr(err)
 
-	xdsServer := xds.NewServer()
 	xdsServer.RegisterServer(ctx, grpcServer, cfg)
 
-	nodeRegistryServer := registry.NewNodeRegistryServer(xdsServer.RWMutex(), xdsServer.ChannelNodes())
+	introspection := http.NewServeMux()
+	xdsServer.RegisterHandlers(introspection)
+
+	if cfg.EnableAccessLog {
+		collector := accesslog.NewCollector(cfg.AccessLogBufferSize)
+		collector.RegisterHandlers(introspection)
+		pbALS.RegisterAccessLogServiceServer(grpcServer, collector)
+	}
+
+	if cfg.EnableTracing {
+		traces := tracing.NewCollector(cfg.TraceBufferSize)
+		traces.RegisterHandlers(introspection)
+
+		go serveTraceCollector(ctx, cfg, traces.ReceiverHandler())
+	}
+
+	go serveIntrospection(ctx, cfg, introspection)
+
+	if cfg.EnableMetrics {
+		go metrics.Serve(ctx, net.JoinHostPort("", strconv.Itoa(cfg.ControlPlaneMetricsPort)))
+	}
+
+	watchAuthorizationPolicies(ctx, cfg, xdsServer.ChannelPolicies())
+	watchRateLimitPolicies(ctx, cfg, xdsServer.ChannelRateLimits())
+
+	if cfg.EnableGlobalRateLimit {
+		pbRLS.RegisterRateLimitServiceServer(grpcServer, ratelimit.NewServer(xdsServer.BundleRateLimit))
+	}
+
+	livenessTracker := registry.NewLivenessTracker(
+		time.Duration(cfg.NodeSuspectTimeout)*config.TimeUnit,
+		time.Duration(cfg.NodeDeadTimeout)*config.TimeUnit,
+		xdsServer.ChannelLiveness(),
+	)
+	go livenessTracker.Run(ctx)
+
+	nodeRegistryServer := registry.NewNodeRegistryServer(
+		xdsServer.RWMutex(),
+		xdsServer.ChannelNodes(),
+		store,
+		time.Duration(cfg.NodeGracePeriod)*config.TimeUnit,
+		livenessTracker,
+	)
+	nodeRegistryServer.UseAuthenticator(auth)
 	pbNode.RegisterNodeRegistryServiceServer(grpcServer, nodeRegistryServer)
 
-	serviceRegSrv := registry.NewServiceRegistryServer(xdsServer.RWMutex(), nodeRegistryServer, xdsServer.ChannelServices())
+	serviceRegSrv := registry.NewServiceRegistryServer(xdsServer.RWMutex(), nodeRegistryServer, xdsServer.ChannelServices(), store)
 	pbService.RegisterServiceRegistryServiceServer(grpcServer, serviceRegSrv)
+	serviceRegSrv.RegisterHandlers(introspection)
+
+	nodeRegistryServer.HandleEviction(serviceRegSrv.EvictNode)
+
+	trafficSrv := registry.NewTrafficServer(xdsServer.RWMutex(), xdsServer.ChannelWeights(), store)
+	pbTraffic.RegisterTrafficServiceServer(grpcServer, trafficSrv)
+
+	faultSrv := registry.NewFaultServer(xdsServer.RWMutex(), xdsServer.ChannelFaults(), time.Duration(cfg.MaxFaultDuration)*config.TimeUnit)
+	pbFault.RegisterFaultServiceServer(grpcServer, faultSrv)
+
+	if cfg.EnableHighAvailability {
+		replicationSrv := registry.NewReplicationServer(store, time.Duration(cfg.ReplicationInterval)*config.TimeUnit)
+		pbReplication.RegisterReplicationServiceServer(grpcServer, replicationSrv)
+
+		go announceLeadership(ctx, cfg, store.Term())
+		go watchPeer(ctx, cfg, store, ca, stop)
+	}
 
 	lis, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(cfg.GRPCPort)))
 	logging.LogErr(err)
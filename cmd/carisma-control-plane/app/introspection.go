@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	httpReadHeaderTimeout = 5 * time.Second
+	httpShutdownTimeout   = 5 * time.Second
+)
+
+// serveIntrospection serves the provided read-only HTTP API until the provided context is done. The API is only
+// reachable from the local host, unless debug mode is enabled.
+func serveIntrospection(ctx context.Context, cfg *config.Config, handler http.Handler) {
+	host := "127.0.0.1"
+	if cfg.EnableDebugMode {
+		host = ""
+	}
+
+	serveHTTP(ctx, net.JoinHostPort(host, strconv.Itoa(cfg.IntrospectionPort)), handler)
+}
+
+// serveTraceCollector serves the receiver of the trace collector, which the Envoys of all nodes report their spans to,
+// until the provided context is done.
+func serveTraceCollector(ctx context.Context, cfg *config.Config, handler http.Handler) {
+	serveHTTP(ctx, net.JoinHostPort("", strconv.Itoa(cfg.TraceCollectorPort)), handler)
+}
+
+func serveHTTP(ctx context.Context, addr string, handler http.Handler) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+
+		logging.LogErr(srv.Shutdown(shutdownCtx))
+	}()
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		logging.LogErr(err)
+	}
+}
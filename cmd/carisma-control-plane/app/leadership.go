@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"context"
+	"errors"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	pbReplication "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/replication/v1"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/udp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"net"
+	"strconv"
+	"time"
+)
+
+// dialPeer creates the connection to the peer control plane.
+func dialPeer(cfg *config.Config, ca *pki.CA) (*grpc.ClientConn, error) {
+	transportCredentials := insecure.NewCredentials()
+	if cfg.EnableControlTLS {
+		tlsConfig, err := ca.PeerTLSConfig(cfg.NodeHostname, cfg.ControlPlanePeer, time.Duration(cfg.NodeCertTTL)*config.TimeUnit)
+		if err != nil {
+			return nil, err
+		}
+
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
+	return grpc.NewClient(
+		net.JoinHostPort(cfg.ControlPlanePeer, strconv.Itoa(cfg.GRPCPort)),
+		grpc.WithTransportCredentials(transportCredentials),
+	)
+}
+
+// newPeerFollower creates a follower of the registry of the peer control plane.
+func newPeerFollower(cfg *config.Config, conn *grpc.ClientConn, store *registry.Store) *registry.Follower {
+	return registry.NewFollower(
+		pbReplication.NewReplicationServiceClient(conn),
+		store,
+		cfg.NodeHostname,
+		time.Duration(cfg.LeaderTimeout)*config.TimeUnit,
+	)
+}
+
+// awaitLeadership replicates the state of the peer control plane into the store as long as the peer leads and returns
+// once this control plane takes over with the next leadership term. A primary control plane takes over if it cannot
+// reach its peer for one replication interval, in which a leading peer replicates its state at least once. A standby
+// control plane waits for the peer to fail.
+func awaitLeadership(ctx context.Context, cfg *config.Config, store *registry.Store, ca *pki.CA) error {
+	if cfg.ControlPlanePeer == "" {
+		return errors.New("high availability requires the hostname of the peer control plane")
+	}
+
+	conn, err := dialPeer(cfg, ca)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		logging.LogErr(conn.Close())
+	}()
+
+	if cfg.EnableStandbyMode {
+		logging.DefaultLogger.Info().Str("Peer", cfg.ControlPlanePeer).Msg("Starting as standby control plane")
+	}
+
+	var probe time.Duration
+	if !cfg.EnableStandbyMode {
+		probe = time.Duration(cfg.ReplicationInterval) * config.TimeUnit
+	}
+
+	err = newPeerFollower(cfg, conn, store).Follow(
+		metadata.NewOutgoingContext(ctx, metadata.Pairs(registry.HeaderAdmissionToken, cfg.AdmissionToken)),
+		probe,
+	)
+	if err != nil {
+		return err
+	}
+
+	term := store.Term() + 1
+	if err := store.SaveTerm(term); err != nil {
+		return err
+	}
+
+	// the snapshots of all nodes point to this control plane from now on
+	cfg.CentralNodeHostname = cfg.NodeHostname
+
+	logging.DefaultLogger.Info().Uint64("Term", term).Msg("Took over leadership of the control plane")
+
+	return nil
+}
+
+// announceLeadership repeatedly broadcasts the leadership of this control plane until the provided context is done, so
+// that the orchestrators re-point their nodes to it. The announcement is sent from an ephemeral port, since the
+// orchestrator on the same host listens at the configured UDP port.
+func announceLeadership(ctx context.Context, cfg *config.Config, term uint64) {
+	sender, err := udp.NewBroadcastSender(ctx, cfg)
+	if err != nil {
+		logging.LogErr(err)
+
+		return
+	}
+	defer func() {
+		logging.LogErr(sender.Close())
+	}()
+
+	err = sender.RepeatedlyWriteMessage(
+		ctx,
+		strconv.Itoa(cfg.UDPPort),
+		udp.NewLeaderMessage(net.JoinHostPort(cfg.NodeHostname, strconv.Itoa(cfg.GRPCPort)), term),
+		time.Duration(cfg.UDPDelay)*config.TimeUnit,
+	)
+	logging.LogErr(err)
+}
+
+// watchPeer steps down once the peer control plane leads with a higher term than this control plane, e.g., as it took
+// over while both were partitioned from each other. Stepping down shuts this control plane down via the provided stop
+// function, so that it follows the peer once it is restarted.
+func watchPeer(ctx context.Context, cfg *config.Config, store *registry.Store, ca *pki.CA, stop context.CancelFunc) {
+	conn, err := dialPeer(cfg, ca)
+	if err != nil {
+		logging.LogErr(err)
+
+		return
+	}
+	defer func() {
+		logging.LogErr(conn.Close())
+	}()
+
+	term := store.Term()
+
+	peerTerm, err := newPeerFollower(cfg, conn, store).AwaitSuperseded(
+		metadata.NewOutgoingContext(ctx, metadata.Pairs(registry.HeaderAdmissionToken, cfg.AdmissionToken)),
+		term,
+		time.Duration(cfg.LeaderTimeout)*config.TimeUnit,
+	)
+	if err != nil {
+		return
+	}
+
+	logging.DefaultLogger.Warn().
+		Uint64("Term", term).
+		Uint64("PeerTerm", peerTerm).
+		Str("Peer", cfg.ControlPlanePeer).
+		Msg("Peer control plane leads with a higher term, stepping down")
+
+	stop()
+}
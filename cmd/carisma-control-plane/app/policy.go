@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	carismaIO "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/io"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"os"
+)
+
+// watchPolicies loads the policies of the provided kind from the provided file and reloads them whenever the file
+// changes. Invalid policies are rejected as a whole, i.e., the previously loaded policies remain in effect.
+func watchPolicies[T any](ctx context.Context, filePath, kind string, fromJSON func([]byte) ([]T, error), policies chan<- []T) {
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		logging.DefaultLogger.Info().
+			Str("File", filePath).
+			Msgf("No %s policies found", kind)
+
+		return
+	}
+
+	policyFile, err := carismaIO.NewFileWatcher(filePath)
+	logging.LogErr(err)
+
+	if err != nil {
+		return
+	}
+
+	policyFile.HandleDiff(func(a, b []byte) {
+		if bytes.Equal(a, b) {
+			return
+		}
+
+		p, err := fromJSON(b)
+		if err != nil {
+			logging.DefaultLogger.Error().Err(err).
+				Str("File", filePath).
+				Msgf("ignoring invalid %s policies", kind)
+
+			return
+		}
+
+		logging.DefaultLogger.Info().
+			Int("Policies", len(p)).
+			Msgf("Loaded %s policies", kind)
+
+		policies <- p
+	})
+
+	go func() {
+		defer policyFile.Close()
+
+		policyFile.Watch(ctx)
+	}()
+
+	// initially load the policies
+	policyFile.Diff(false)
+}
+
+// watchAuthorizationPolicies watches the configured authorization policies. Without policies, all bundles may call each
+// other.
+func watchAuthorizationPolicies(ctx context.Context, cfg *config.Config, policies chan<- []config.AuthorizationPolicy) {
+	watchPolicies(ctx, cfg.AuthorizationPolicyFilePath, "authorization", config.AuthorizationPoliciesFromJSON, policies)
+}
+
+// watchRateLimitPolicies watches the configured rate limit policies. Without policies, only the rate limits declared by
+// the bundles apply.
+func watchRateLimitPolicies(ctx context.Context, cfg *config.Config, policies chan<- []config.RateLimitPolicy) {
+	watchPolicies(ctx, cfg.RateLimitPolicyFilePath, "rate limit", config.RateLimitPoliciesFromJSON, policies)
+}
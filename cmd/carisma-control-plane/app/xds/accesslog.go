@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	accesslogconfig "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	grpcaccesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/accesslog"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	accessLogName = "carisma"
+)
+
+// makeAccessLogs creates the access log that streams the requests of the local bundles to the access log service of
+// the control plane. Only the calling node logs a request, so that every request is recorded exactly once.
+func makeAccessLogs() ([]*accesslogconfig.AccessLog, error) {
+	alsConfig, err := anypb.New(&grpcaccesslog.HttpGrpcAccessLogConfig{
+		CommonConfig: &grpcaccesslog.CommonGrpcAccessLogConfig{
+			LogName: accessLogName,
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: config.XDSClusterName},
+				},
+			},
+			TransportApiVersion: core.ApiVersion_V3,
+		},
+		AdditionalRequestHeadersToLog:   []string{accesslog.HeaderCaller},
+		AdditionalResponseHeadersToLog:  []string{accesslog.HeaderGRPCStatus},
+		AdditionalResponseTrailersToLog: []string{accesslog.HeaderGRPCStatus},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*accesslogconfig.AccessLog{{
+		Name:       wellknown.HTTPGRPCAccessLog,
+		ConfigType: &accesslogconfig.AccessLog_TypedConfig{TypedConfig: alsConfig},
+	}}, nil
+}
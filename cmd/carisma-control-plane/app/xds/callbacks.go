@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"time"
+)
+
+// ResourceStatus encodes the version of a resource type that a node accepted (ACK) or rejected (NACK) most recently.
+type ResourceStatus struct {
+	AckedVersion  string    `json:"ackedVersion,omitempty"`
+	NackedVersion string    `json:"nackedVersion,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	Updated       time.Time `json:"updated"`
+}
+
+// sentResponse encodes the most recent response of one resource type sent on a stream.
+type sentResponse struct {
+	nonce   string
+	version string
+}
+
+// stream encodes the state of an ADS stream. Envoy only presents its node in the first request of a stream.
+type stream struct {
+	nodeID string
+	peer   net.Addr
+	sent   map[string]sentResponse
+}
+
+// validateFunc checks whether the Envoy that connected from the provided peer address may act as the provided node.
+type validateFunc func(nodeID string, peer net.Addr) error
+
+// callbacks tracks the ADS streams of the nodes, rejects streams of Envoys that cannot prove to be the node they claim
+// to be, and records which versions of the resources the nodes ACKed or NACKed.
+type callbacks struct {
+	validate validateFunc
+
+	mu      sync.Mutex // protects streams and status
+	streams map[int64]*stream
+	status  map[string]map[string]ResourceStatus
+}
+
+func newCallbacks() *callbacks {
+	return &callbacks{
+		validate: func(string, net.Addr) error { return nil },
+		streams:  make(map[int64]*stream),
+		status:   make(map[string]map[string]ResourceStatus),
+	}
+}
+
+func (c *callbacks) openStream(ctx context.Context, streamID int64) {
+	s := &stream{sent: make(map[string]sentResponse)}
+	if p, ok := peer.FromContext(ctx); ok {
+		s.peer = p.Addr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.streams[streamID] = s
+
+	metricADSStreams.Inc()
+}
+
+func (c *callbacks) closeStream(streamID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.streams[streamID]; ok {
+		delete(c.streams, streamID)
+
+		metricADSStreams.Dec()
+	}
+}
+
+func logRejection(nodeID string, addr net.Addr, err error) {
+	logging.SecurityEvent().Err(err).
+		Str("Node", nodeID).
+		Stringer("Peer", addr).
+		Msg("Rejecting xDS request")
+}
+
+// identify binds the provided stream to the node presented by the Envoy, if the Envoy may act as this node. Otherwise,
+// the stream is rejected and the attempt is logged as security event.
+func (c *callbacks) identify(streamID int64, node *core.Node) error {
+	if node == nil || node.Id == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	s, ok := c.streams[streamID]
+	var nodeID string
+	var addr net.Addr
+	if ok {
+		nodeID, addr = s.nodeID, s.peer
+	}
+	c.mu.Unlock()
+
+	if !ok || nodeID == node.Id {
+		return nil
+	}
+
+	err := status.Error(codes.PermissionDenied, errorMsgNodeIDChanged)
+	if nodeID == "" {
+		err = c.validate(node.Id, addr)
+	}
+
+	if err != nil {
+		logRejection(node.Id, addr, err)
+
+		return err
+	}
+
+	c.mu.Lock()
+	s.nodeID = node.Id
+	c.mu.Unlock()
+
+	return nil
+}
+
+// onRequest records whether the provided request ACKs or NACKs the most recent response of its resource type. Requests
+// that refer to an outdated response are ignored, as Envoy only processes the most recent one.
+func (c *callbacks) onRequest(streamID int64, typeURL, nonce string, errorDetail *rpcstatus.Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.streams[streamID]
+	if !ok {
+		return
+	}
+
+	sent, ok := s.sent[typeURL]
+	if !ok || nonce == "" || nonce != sent.nonce || s.nodeID == "" {
+		return
+	}
+
+	if _, ok := c.status[s.nodeID]; !ok {
+		c.status[s.nodeID] = make(map[string]ResourceStatus)
+	}
+
+	resourceStatus := c.status[s.nodeID][typeURL]
+	resourceStatus.Updated = time.Now()
+
+	if errorDetail != nil {
+		resourceStatus.NackedVersion = sent.version
+		resourceStatus.Error = errorDetail.Message
+
+		metricNACKs.Inc(s.nodeID, typeURL)
+
+		logging.DefaultLogger.Warn().
+			Str("Node", s.nodeID).
+			Str("Type", typeURL).
+			Str("Version", sent.version).
+			Str("Error", errorDetail.Message).
+			Msg("Envoy rejected configuration")
+	} else {
+		resourceStatus.AckedVersion = sent.version
+		resourceStatus.NackedVersion = ""
+		resourceStatus.Error = ""
+	}
+
+	c.status[s.nodeID][typeURL] = resourceStatus
+}
+
+func (c *callbacks) onResponse(streamID int64, typeURL, nonce, version string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.streams[streamID]; ok {
+		s.sent[typeURL] = sentResponse{nonce: nonce, version: version}
+	}
+}
+
+// nodeStatus returns the status of the resource types of the provided node keyed by their type URL.
+func (c *callbacks) nodeStatus(nodeID string) map[string]ResourceStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make(map[string]ResourceStatus, len(c.status[nodeID]))
+	for typeURL, s := range c.status[nodeID] {
+		statuses[typeURL] = s
+	}
+
+	return statuses
+}
+
+// forgetNode drops the status of a node that left the registry.
+func (c *callbacks) forgetNode(nodeID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.status, nodeID)
+}
+
+func (c *callbacks) OnStreamOpen(ctx context.Context, streamID int64, _ string) error {
+	c.openStream(ctx, streamID)
+
+	return nil
+}
+
+func (c *callbacks) OnStreamClosed(streamID int64, _ *core.Node) {
+	c.closeStream(streamID)
+}
+
+func (c *callbacks) OnDeltaStreamOpen(ctx context.Context, streamID int64, _ string) error {
+	c.openStream(ctx, streamID)
+
+	return nil
+}
+
+func (c *callbacks) OnDeltaStreamClosed(streamID int64, _ *core.Node) {
+	c.closeStream(streamID)
+}
+
+func (c *callbacks) OnStreamRequest(streamID int64, req *discovery.DiscoveryRequest) error {
+	if err := c.identify(streamID, req.Node); err != nil {
+		return err
+	}
+
+	c.onRequest(streamID, req.TypeUrl, req.ResponseNonce, req.ErrorDetail)
+
+	return nil
+}
+
+func (c *callbacks) OnStreamResponse(_ context.Context, streamID int64, _ *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse) {
+	c.onResponse(streamID, resp.TypeUrl, resp.Nonce, resp.VersionInfo)
+}
+
+func (c *callbacks) OnStreamDeltaRequest(streamID int64, req *discovery.DeltaDiscoveryRequest) error {
+	if err := c.identify(streamID, req.Node); err != nil {
+		return err
+	}
+
+	c.onRequest(streamID, req.TypeUrl, req.ResponseNonce, req.ErrorDetail)
+
+	return nil
+}
+
+func (c *callbacks) OnStreamDeltaResponse(streamID int64, _ *discovery.DeltaDiscoveryRequest, resp *discovery.DeltaDiscoveryResponse) {
+	c.onResponse(streamID, resp.TypeUrl, resp.Nonce, resp.SystemVersionInfo)
+}
+
+func (c *callbacks) OnFetchRequest(ctx context.Context, req *discovery.DiscoveryRequest) error {
+	var addr net.Addr
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr
+	}
+
+	if err := c.validate(req.GetNode().GetId(), addr); err != nil {
+		logRejection(req.GetNode().GetId(), addr, err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (c *callbacks) OnFetchResponse(_ *discovery.DiscoveryRequest, _ *discovery.DiscoveryResponse) {}
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+// newTestCallbacks creates callbacks that validate the nodes against the nodes of the test server, see newTestServer.
+func newTestCallbacks() *callbacks {
+	x := newTestServer(registry.ServiceConfigSnapshot{})
+
+	cfg := config.Default()
+	cfg.NodeHostname = "hpc-1"
+	cfg.EnableXDSPeerValidation = true
+
+	c := newCallbacks()
+	c.validate = func(nodeID string, addr net.Addr) error {
+		return x.validateNode(context.Background(), cfg, nodeID, addr)
+	}
+
+	return c
+}
+
+// openTestStream opens a stream of an Envoy that connects from the provided IP address.
+func openTestStream(c *callbacks, streamID int64, ip string) {
+	c.openStream(peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}}), streamID)
+}
+
+func TestIdentify(t *testing.T) {
+	tests := []struct {
+		name       string
+		peerIP     string
+		nodeIDs    []string
+		wantErr    string
+		wantNodeID string
+	}{
+		{
+			name:       "registered node connects from its host",
+			peerIP:     "10.0.0.2",
+			nodeIDs:    []string{testRemoteNodeID1},
+			wantNodeID: testRemoteNodeID1,
+		},
+		{
+			name:       "Envoy only presents its node in the first request",
+			peerIP:     "10.0.0.2",
+			nodeIDs:    []string{testRemoteNodeID1, "", testRemoteNodeID1},
+			wantNodeID: testRemoteNodeID1,
+		},
+		{
+			name:       "node of the control plane connects via loopback",
+			peerIP:     "127.0.0.1",
+			nodeIDs:    []string{testLocalNodeID},
+			wantNodeID: testLocalNodeID,
+		},
+		{
+			name:       "node ID changed within the stream",
+			peerIP:     "10.0.0.2",
+			nodeIDs:    []string{testRemoteNodeID1, testRemoteNodeID2},
+			wantErr:    errorMsgNodeIDChanged,
+			wantNodeID: testRemoteNodeID1,
+		},
+		{
+			name:    "unknown node",
+			peerIP:  "10.0.0.2",
+			nodeIDs: []string{"node-unknown"},
+			wantErr: errorMsgUnknownNode,
+		},
+		{
+			name:    "registered node connects from the host of another node",
+			peerIP:  "10.0.0.2",
+			nodeIDs: []string{testRemoteNodeID2},
+			wantErr: errorMsgPeerMismatch,
+		},
+		{
+			name:    "other node connects via loopback",
+			peerIP:  "127.0.0.1",
+			nodeIDs: []string{testRemoteNodeID1},
+			wantErr: errorMsgPeerMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCallbacks()
+			openTestStream(c, 1, test.peerIP)
+
+			var err error
+			for _, nodeID := range test.nodeIDs {
+				var node *core.Node
+				if nodeID != "" {
+					node = &core.Node{Id: nodeID}
+				}
+
+				err = c.identify(1, node)
+			}
+
+			if test.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Equal(t, status.Convert(err).Message(), test.wantErr)
+			}
+
+			assert.Equal(t, c.streams[1].nodeID, test.wantNodeID)
+		})
+	}
+}
+
+// ackEvent is either a response sent on a stream or a request that refers to a response by its nonce.
+type ackEvent struct {
+	response bool
+	typeURL  string
+	nonce    string
+	version  string
+	err      string
+}
+
+func sent(typeURL, nonce, version string) ackEvent {
+	return ackEvent{response: true, typeURL: typeURL, nonce: nonce, version: version}
+}
+
+func acked(typeURL, nonce string) ackEvent {
+	return ackEvent{typeURL: typeURL, nonce: nonce}
+}
+
+func nacked(typeURL, nonce, err string) ackEvent {
+	return ackEvent{typeURL: typeURL, nonce: nonce, err: err}
+}
+
+func TestOnRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		unbound    bool
+		events     []ackEvent
+		wantStatus map[string]ResourceStatus
+	}{
+		{
+			name:       "the initial request does not refer to any response",
+			events:     []ackEvent{acked(resource.ClusterType, "")},
+			wantStatus: map[string]ResourceStatus{},
+		},
+		{
+			name:   "ACK of the most recent response",
+			events: []ackEvent{sent(resource.ClusterType, "n1", "1.0"), acked(resource.ClusterType, "n1")},
+			wantStatus: map[string]ResourceStatus{
+				resource.ClusterType: {AckedVersion: "1.0"},
+			},
+		},
+		{
+			name: "requests that refer to an outdated response are ignored",
+			events: []ackEvent{
+				sent(resource.ClusterType, "n1", "1.0"),
+				acked(resource.ClusterType, "n1"),
+				sent(resource.ClusterType, "n2", "2.0"),
+				nacked(resource.ClusterType, "n1", "outdated"),
+			},
+			wantStatus: map[string]ResourceStatus{
+				resource.ClusterType: {AckedVersion: "1.0"},
+			},
+		},
+		{
+			name: "a NACK keeps the version that was accepted most recently",
+			events: []ackEvent{
+				sent(resource.ClusterType, "n1", "1.0"),
+				acked(resource.ClusterType, "n1"),
+				sent(resource.ClusterType, "n2", "2.0"),
+				nacked(resource.ClusterType, "n2", "invalid cluster"),
+			},
+			wantStatus: map[string]ResourceStatus{
+				resource.ClusterType: {AckedVersion: "1.0", NackedVersion: "2.0", Error: "invalid cluster"},
+			},
+		},
+		{
+			name: "an ACK of a later version resolves the NACK",
+			events: []ackEvent{
+				sent(resource.ClusterType, "n1", "1.0"),
+				nacked(resource.ClusterType, "n1", "invalid cluster"),
+				sent(resource.ClusterType, "n2", "2.0"),
+				acked(resource.ClusterType, "n2"),
+			},
+			wantStatus: map[string]ResourceStatus{
+				resource.ClusterType: {AckedVersion: "2.0"},
+			},
+		},
+		{
+			name: "the nonces of the resource types are tracked separately",
+			events: []ackEvent{
+				sent(resource.ClusterType, "n1", "1.0"),
+				sent(resource.ListenerType, "n2", "1.0"),
+				acked(resource.ClusterType, "n2"),
+				acked(resource.ListenerType, "n2"),
+			},
+			wantStatus: map[string]ResourceStatus{
+				resource.ListenerType: {AckedVersion: "1.0"},
+			},
+		},
+		{
+			name:       "streams that are not bound to a node are ignored",
+			unbound:    true,
+			events:     []ackEvent{sent(resource.ClusterType, "n1", "1.0"), acked(resource.ClusterType, "n1")},
+			wantStatus: map[string]ResourceStatus{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCallbacks()
+			openTestStream(c, 1, "10.0.0.2")
+
+			if !test.unbound {
+				assert.NilError(t, c.identify(1, &core.Node{Id: testRemoteNodeID1}))
+			}
+
+			for _, e := range test.events {
+				if e.response {
+					c.onResponse(1, e.typeURL, e.nonce, e.version)
+
+					continue
+				}
+
+				var errorDetail *rpcstatus.Status
+				if e.err != "" {
+					errorDetail = &rpcstatus.Status{Message: e.err}
+				}
+
+				c.onRequest(1, e.typeURL, e.nonce, errorDetail)
+			}
+
+			resourceStatus := c.nodeStatus(testRemoteNodeID1)
+			for typeURL, s := range resourceStatus {
+				assert.Assert(t, !s.Updated.IsZero())
+
+				s.Updated = time.Time{}
+				resourceStatus[typeURL] = s
+			}
+
+			assert.DeepEqual(t, resourceStatus, test.wantStatus)
+
+			c.forgetNode(testRemoteNodeID1)
+			assert.Equal(t, len(c.nodeStatus(testRemoteNodeID1)), 0)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/anypb"
+	"strings"
+)
+
+const (
+	// Header that carries the caller token of the calling bundle, see container.CallerTokenEnv. It never leaves the node.
+	callerTokenHeader = "x-carisma-caller-token"
+
+	// Name of the filter that identifies the calling bundle by its caller token.
+	callerIdentityFilterName = "carisma.filters.http.caller_identity"
+
+	callerIdentityScript = `local callers = {
+%s}
+
+function envoy_on_request(handle)
+  local headers = handle:headers()
+  local caller = callers[headers:get(%s) or ""]
+
+  headers:remove(%s)
+
+  if caller then
+    headers:replace(%s, caller)
+  else
+    headers:remove(%s)
+  end
+end
+`
+)
+
+// localCallers returns the bundle IDs of the instances on the provided node keyed by their caller token.
+func (x *Server) localCallers(nodeID string) map[string]string {
+	callers := make(map[string]string)
+	for bundleID, service := range x.services[nodeID] {
+		for _, token := range service.CallerTokens {
+			callers[token] = bundleID
+		}
+	}
+
+	return callers
+}
+
+// luaString quotes the provided string as Lua string literal. All characters except letters, digits, dashes and dots
+// are escaped, so that neither bundle IDs nor caller tokens can alter the script.
+func luaString(s string) string {
+	var sb strings.Builder
+	sb.WriteRune('"')
+
+	for _, b := range []byte(s) {
+		if (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') || b == '-' || b == '.' {
+			sb.WriteByte(b)
+		} else {
+			sb.WriteString(fmt.Sprintf("\\%03d", b))
+		}
+	}
+
+	sb.WriteRune('"')
+
+	return sb.String()
+}
+
+// makeCallerIdentityFilter creates the filter that replaces the caller header of every request with the ID of the
+// bundle that presented the provided caller token, or removes it if the caller is unknown. As the caller header is
+// never taken from the bundle itself, the bundles cannot impersonate each other towards the authorization policies and
+// rate limits, see makeSourcePrincipal and makeCallerAction. The filter precedes all other filters of the egress listeners.
+func makeCallerIdentityFilter(callers map[string]string) (*hcm.HttpFilter, error) {
+	// keep the order of the callers stable, so that an unchanged configuration results in an unchanged resource
+	tokens := maps.Keys(callers)
+	slices.Sort(tokens)
+
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString(fmt.Sprintf("  [%s] = %s,\n", luaString(token), luaString(callers[token])))
+	}
+
+	script := fmt.Sprintf(callerIdentityScript, sb.String(), luaString(callerTokenHeader), luaString(callerTokenHeader),
+		luaString(callerHeader), luaString(callerHeader))
+
+	luaConfig, err := anypb.New(&lua.Lua{
+		DefaultSourceCode: &core.DataSource{Specifier: &core.DataSource_InlineString{InlineString: script}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       callerIdentityFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: luaConfig},
+	}, nil
+}
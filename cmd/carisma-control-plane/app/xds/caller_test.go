@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"strings"
+	"testing"
+)
+
+func TestLuaString(t *testing.T) {
+	assert.Equal(t, luaString("node-hpc-1.vehicle"), `"node-hpc-1.vehicle"`)
+	assert.Equal(t, luaString(`a"] = os.exit() --`), `"a\034\093\032\061\032os.exit\040\041\032--"`)
+}
+
+func TestCallerIdentityFilter(t *testing.T) {
+	x := newTestServer(registry.ServiceConfigSnapshot{
+		testLocalNodeID: {
+			"brake":     {Versions: map[string][]int32{"v1": {8080}}, CallerTokens: []string{"b2", "b1"}},
+			"dashboard": {Versions: map[string][]int32{"v1": {8081}}, CallerTokens: []string{"d1"}},
+		},
+		testRemoteNodeID1: {
+			"radio": {Versions: map[string][]int32{"v1": {8080}}, CallerTokens: []string{"r1"}},
+		},
+	})
+
+	callers := x.localCallers(testLocalNodeID)
+	assert.DeepEqual(t, callers, map[string]string{"b1": "brake", "b2": "brake", "d1": "dashboard"})
+
+	filter, err := makeCallerIdentityFilter(callers)
+	assert.NilError(t, err)
+
+	luaConfig := &lua.Lua{}
+	assert.NilError(t, filter.GetTypedConfig().UnmarshalTo(luaConfig))
+
+	// the callers are ordered by their token and the tokens of remote bundles are unknown
+	script := luaConfig.DefaultSourceCode.GetInlineString()
+	assert.Assert(t, strings.Contains(script, `
+  ["b1"] = "brake",
+  ["b2"] = "brake",
+  ["d1"] = "dashboard",
+}`))
+	assert.Assert(t, !strings.Contains(script, "r1"))
+	assert.Assert(t, strings.Contains(script, `headers:replace("x-carisma-caller", caller)`))
+	assert.Assert(t, strings.Contains(script, `headers:remove("x-carisma-caller-token")`))
+}
+
+func TestCallerIdentityPrecedesEgressFilters(t *testing.T) {
+	x := newTestServer(registry.ServiceConfigSnapshot{})
+
+	listeners, err := x.makeHTTPListener(testLocalNodeID, config.Default())
+	assert.NilError(t, err)
+
+	for _, res := range listeners {
+		l := res.(*listener.Listener)
+
+		manager := &hcm.HttpConnectionManager{}
+		assert.NilError(t, l.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(manager))
+
+		// the ingress listeners receive the caller header from the egress listener of the calling node
+		if l.TrafficDirection == core.TrafficDirection_INBOUND {
+			assert.Equal(t, manager.HttpFilters[0].Name, wellknown.HTTPRoleBasedAccessControl)
+
+			continue
+		}
+
+		assert.Equal(t, manager.HttpFilters[0].Name, callerIdentityFilterName)
+	}
+}
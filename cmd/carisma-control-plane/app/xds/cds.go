@@ -4,117 +4,147 @@
 package xds
 
 import (
-	"errors"
 	"fmt"
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
-	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
-	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
-	"google.golang.org/protobuf/types/known/durationpb"
-	"time"
 )
 
-func generateClusterName(bundleID string, isLocal bool) string {
+// versionInstances encodes where the instances of one version of a bundle run from the perspective of a node.
+type versionInstances struct {
+	localPorts    []int32
+	remoteNodeIDs []string
+}
+
+func generateClusterName(bundleID, version string, isLocal bool) string {
+	name := bundleID
+	if version != "" {
+		name = fmt.Sprintf("%v_%v", bundleID, version)
+	}
+
 	if isLocal {
-		return fmt.Sprintf("local_%v_cluster", bundleID)
+		return fmt.Sprintf("local_%v_cluster", name)
 	} else {
-		return fmt.Sprintf("%v_cluster", bundleID)
+		return fmt.Sprintf("%v_cluster", name)
 	}
 }
 
-func (x *Server) makeEndpoints(nodeID, bundleID string, ports []int32) ([]*endpoint.LocalityLbEndpoints, error) {
-	nodeIdx, err := registry.GetNodeIdx(nodeID)
-	if err != nil {
-		return nil, err
-	}
-	if nodeIdx >= len(x.nodes) || nodeIdx < len(x.nodes) {
-		return nil, errors.New("invalid node ID")
-	}
+// bundleVersions groups the instances of all bundles by bundle version from the perspective of the provided node.
+func (x *Server) bundleVersions(localNodeID string) map[string]map[string]*versionInstances {
+	bundles := make(map[string]map[string]*versionInstances)
 
-	nodeAddress := x.nodes[nodeIdx].(*registry.NodeAddr).Host
-
-	endpoints := make([]*endpoint.LocalityLbEndpoints, 0)
-	for _, port := range ports {
-		localityEndpoints := endpoint.LocalityLbEndpoints{
-			LbEndpoints: []*endpoint.LbEndpoint{{
-				HostIdentifier: &endpoint.LbEndpoint_Endpoint{
-					Endpoint: &endpoint.Endpoint{
-						Address: &core.Address{
-							Address: &core.Address_SocketAddress{
-								SocketAddress: &core.SocketAddress{
-									Protocol: core.SocketAddress_TCP,
-									Address:  nodeAddress,
-									PortSpecifier: &core.SocketAddress_PortValue{
-										PortValue: uint32(port),
-									},
-								},
-							},
-						},
-					},
-				},
-			}},
+	nodeIDs := maps.Keys(x.services)
+	slices.Sort(nodeIDs)
+
+	for _, nodeID := range nodeIDs {
+		// do not route to nodes that are considered dead
+		if nodeID != localNodeID && x.nodeHealth[nodeID] == registry.NodeHealthDead {
+			continue
 		}
 
-		e := logging.DefaultLogger.Debug().
-			Str("Node", nodeID).
-			Str("Bundle", bundleID).
-			Str("Address", nodeAddress).
-			Int("Port", int(port))
+		// bundles that only serve TCP or UDP service ports are handled by servicePorts
+		for bundleID, service := range x.services[nodeID] {
+			for version, ports := range service.Versions {
+				// do not route to remote nodes whose instances of a version are all reported unhealthy
+				if nodeID != localNodeID && !service.Healthy(version) {
+					continue
+				}
+
+				if _, ok := bundles[bundleID]; !ok {
+					bundles[bundleID] = make(map[string]*versionInstances)
+				}
+
+				instances, ok := bundles[bundleID][version]
+				if !ok {
+					instances = &versionInstances{}
+					bundles[bundleID][version] = instances
+				}
+
+				if nodeID == localNodeID {
+					instances.localPorts = ports
+				} else {
+					instances.remoteNodeIDs = append(instances.remoteNodeIDs, nodeID)
+				}
+			}
+		}
+	}
 
-		e.Msg("Registering service endpoint")
+	return bundles
+}
 
-		endpoints = append(endpoints, &localityEndpoints)
+func (x *Server) makeCluster(clusterID string, protocol config.Protocol, policy *config.TrafficPolicy) *cluster.Cluster {
+	logging.DefaultLogger.Debug().
+		Str("Cluster", clusterID).
+		Str("Protocol", string(protocol)).
+		Msg("Registering cluster")
+
+	c := &cluster.Cluster{
+		Name:                          clusterID,
+		ClusterDiscoveryType:          &cluster.Cluster_Type{Type: cluster.Cluster_EDS},
+		TypedExtensionProtocolOptions: upstreamProtocolOptions(protocol),
+		EdsClusterConfig: &cluster.Cluster_EdsClusterConfig{
+			EdsConfig:   getConfigSource(),
+			ServiceName: clusterID,
+		},
 	}
+	applyClusterPolicy(c, policy)
+	applyHealthCheck(c, protocol, policy)
 
-	return endpoints, nil
+	return c
 }
 
-func (x *Server) makeClusters(localNodeID string, ingressPort int32) ([]types.Resource, error) {
+// makeClusters creates a single cluster per bundle version that local callers are forwarded to. It contains the local
+// instances at the highest priority and the instances on remote nodes, which are reached via the ingress listener of
+// their node, at a lower priority, see makeLoadAssignment. The ingress listener forwards requests from remote nodes to
+// an additional cluster that only contains the local instances, so that requests are never passed on to a third node.
+// Safety-relevant bundles are reached via the ingress listener of the safety traffic class, see trafficClass.
+func (x *Server) makeClusters(localNodeID string, cfg *config.Config) ([]types.Resource, []types.Resource) {
 	clusters := make([]types.Resource, 0)
+	loadAssignments := make([]types.Resource, 0)
 
-	seen := make([]string, 0)
-	for nodeID, serviceConfig := range x.services {
-		for bundleID, instances := range serviceConfig {
-			clusterID := generateClusterName(bundleID, false)
-			ports := []int32{ingressPort}
-			if nodeID == localNodeID {
-				clusterID = generateClusterName(bundleID, true)
-				ports = instances
-			}
+	for bundleID, versions := range x.bundleVersions(localNodeID) {
+		policy := x.bundlePolicy(bundleID)
+		protocol := x.bundleProtocol(bundleID)
+		criticality := x.bundleCriticality(bundleID)
+		ingressPort := int32(classOf(criticality).ingressPort(cfg))
 
-			if slices.Contains(seen, clusterID) {
-				continue
+		for version, instances := range versions {
+			nodePorts := make(map[string][]int32, len(instances.remoteNodeIDs)+1)
+
+			if len(instances.localPorts) > 0 {
+				clusterID := generateClusterName(bundleID, version, true)
+				nodePorts[localNodeID] = instances.localPorts
+
+				localCluster := x.makeCluster(clusterID, protocol, policy)
+				applyCriticality(localCluster, criticality)
+
+				clusters = append(clusters, localCluster)
+				loadAssignments = append(loadAssignments, x.makeLoadAssignment(clusterID, bundleID, localNodeID, map[string][]int32{
+					localNodeID: instances.localPorts,
+				}))
 			}
 
-			seen = append(seen, clusterID)
+			for _, nodeID := range instances.remoteNodeIDs {
+				nodePorts[nodeID] = []int32{ingressPort}
+			}
 
-			logging.DefaultLogger.Debug().
-				Str("Cluster", clusterID).
-				Msg("Registering cluster")
+			clusterID := generateClusterName(bundleID, version, false)
 
-			endpoints, err := x.makeEndpoints(nodeID, bundleID, ports)
-			if err != nil {
-				return []types.Resource{}, err
+			c := x.makeCluster(clusterID, protocol, policy)
+			if x.mTLSEnabled() {
+				c.TransportSocketMatches = makeRemoteTransportSocketMatches(upstreamALPNProtocols(protocol))
 			}
+			applyOutlierDetection(c, policy)
+			applyCriticality(c, criticality)
 
-			clusters = append(clusters, &cluster.Cluster{
-				Name:                          clusterID,
-				DnsLookupFamily:               cluster.Cluster_V4_ONLY,
-				ConnectTimeout:                durationpb.New(1 * time.Second),
-				ClusterDiscoveryType:          &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
-				LbPolicy:                      cluster.Cluster_ROUND_ROBIN,
-				TypedExtensionProtocolOptions: config.HTTP2ProtocolOptions(),
-				LoadAssignment: &endpoint.ClusterLoadAssignment{
-					ClusterName: clusterID,
-					Endpoints:   endpoints,
-				},
-			})
+			clusters = append(clusters, c)
+			loadAssignments = append(loadAssignments, x.makeLoadAssignment(clusterID, bundleID, localNodeID, nodePorts))
 		}
 	}
 
-	return clusters, nil
+	return clusters, loadAssignments
 }
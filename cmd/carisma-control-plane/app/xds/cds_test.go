@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+// endpointSummary encodes the endpoints of the provided load assignment as "<node>/<priority>/<address>", followed by
+// " remote" for endpoints behind the ingress listener of a remote node.
+func endpointSummary(loadAssignment *endpoint.ClusterLoadAssignment) []string {
+	summary := make([]string, 0)
+	for _, locality := range loadAssignment.Endpoints {
+		for _, e := range locality.LbEndpoints {
+			address := e.GetEndpoint().GetAddress().GetSocketAddress()
+
+			s := fmt.Sprintf("%v/%v/%v:%v", locality.Locality.Zone, locality.Priority, address.Address, address.GetPortValue())
+			if e.Metadata != nil {
+				s += " remote"
+			}
+
+			summary = append(summary, s)
+		}
+	}
+
+	return summary
+}
+
+func TestMakeClusters(t *testing.T) {
+	tests := []struct {
+		name       string
+		services   registry.ServiceConfigSnapshot
+		nodeHealth map[string]registry.NodeHealth
+		want       map[string][]string
+	}{
+		{
+			name: "local instances precede remote instances",
+			services: registry.ServiceConfigSnapshot{
+				testLocalNodeID:   {"brake": {Versions: map[string][]int32{"v1": {8080, 8081}}}},
+				testRemoteNodeID1: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+				testRemoteNodeID2: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+			},
+			want: map[string][]string{
+				"local_brake_v1_cluster": {"node-hpc-1/0/10.0.0.1:8080", "node-hpc-1/0/10.0.0.1:8081"},
+				"brake_v1_cluster": {
+					"node-hpc-1/0/10.0.0.1:8080",
+					"node-hpc-1/0/10.0.0.1:8081",
+					"node-hpc-2/1/10.0.0.2:8000 remote",
+					"node-hpc-3/1/10.0.0.3:8000 remote",
+				},
+			},
+		},
+		{
+			name: "remote instances take the highest priority without local instances",
+			services: registry.ServiceConfigSnapshot{
+				testRemoteNodeID1: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+				testRemoteNodeID2: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+			},
+			want: map[string][]string{
+				"brake_v1_cluster": {"node-hpc-2/0/10.0.0.2:8000 remote", "node-hpc-3/0/10.0.0.3:8000 remote"},
+			},
+		},
+		{
+			name: "dead remote nodes are skipped, but never the local node",
+			services: registry.ServiceConfigSnapshot{
+				testLocalNodeID:   {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+				testRemoteNodeID1: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+				testRemoteNodeID2: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+			},
+			nodeHealth: map[string]registry.NodeHealth{testLocalNodeID: registry.NodeHealthDead, testRemoteNodeID1: registry.NodeHealthDead},
+			want: map[string][]string{
+				"local_brake_v1_cluster": {"node-hpc-1/0/10.0.0.1:8080"},
+				"brake_v1_cluster":       {"node-hpc-1/0/10.0.0.1:8080", "node-hpc-3/1/10.0.0.3:8000 remote"},
+			},
+		},
+		{
+			name: "remote versions whose instances are all unhealthy are skipped, but never the local version",
+			services: registry.ServiceConfigSnapshot{
+				testLocalNodeID: {"brake": {
+					Versions:  map[string][]int32{"v1": {8080}},
+					Unhealthy: map[string][]int32{"v1": {8080}},
+				}},
+				testRemoteNodeID1: {"brake": {
+					Versions:  map[string][]int32{"v1": {8080, 8081}},
+					Unhealthy: map[string][]int32{"v1": {8080, 8081}},
+				}},
+				testRemoteNodeID2: {"brake": {
+					Versions:  map[string][]int32{"v1": {8080, 8081}},
+					Unhealthy: map[string][]int32{"v1": {8080}},
+				}},
+			},
+			want: map[string][]string{
+				"local_brake_v1_cluster": {"node-hpc-1/0/10.0.0.1:8080"},
+				"brake_v1_cluster":       {"node-hpc-1/0/10.0.0.1:8080", "node-hpc-3/1/10.0.0.3:8000 remote"},
+			},
+		},
+		{
+			name: "versions get separate clusters",
+			services: registry.ServiceConfigSnapshot{
+				testLocalNodeID:   {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+				testRemoteNodeID1: {"brake": {Versions: map[string][]int32{"v2": {8080}}}},
+			},
+			want: map[string][]string{
+				"local_brake_v1_cluster": {"node-hpc-1/0/10.0.0.1:8080"},
+				"brake_v1_cluster":       {"node-hpc-1/0/10.0.0.1:8080"},
+				"brake_v2_cluster":       {"node-hpc-2/0/10.0.0.2:8000 remote"},
+			},
+		},
+		{
+			name: "safety-relevant bundles are reached via the safety ingress listener",
+			services: registry.ServiceConfigSnapshot{
+				testLocalNodeID:   {"brake": {Versions: map[string][]int32{"v1": {8080}}, Criticality: config.CriticalityASILB}},
+				testRemoteNodeID1: {"brake": {Versions: map[string][]int32{"v1": {8080}}, Criticality: config.CriticalityASILB}},
+			},
+			want: map[string][]string{
+				"local_brake_v1_cluster": {"node-hpc-1/0/10.0.0.1:8080"},
+				"brake_v1_cluster":       {"node-hpc-1/0/10.0.0.1:8080", "node-hpc-2/1/10.0.0.2:8001 remote"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := newTestServer(test.services)
+			for nodeID, health := range test.nodeHealth {
+				x.nodeHealth[nodeID] = health
+			}
+
+			_, loadAssignments := x.makeClusters(testLocalNodeID, config.Default())
+
+			got := make(map[string][]string, len(loadAssignments))
+			for _, r := range loadAssignments {
+				loadAssignment := r.(*endpoint.ClusterLoadAssignment)
+				got[loadAssignment.ClusterName] = endpointSummary(loadAssignment)
+			}
+
+			assert.DeepEqual(t, got, test.want)
+		})
+	}
+}
+
+func TestMakeLoadAssignment(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodePorts map[string][]int32
+		want      []string
+	}{
+		{
+			name:      "local instances only",
+			nodePorts: map[string][]int32{testLocalNodeID: {8080, 8081}},
+			want:      []string{"node-hpc-1/0/10.0.0.1:8080", "node-hpc-1/0/10.0.0.1:8081"},
+		},
+		{
+			name:      "remote nodes at the next lower priority",
+			nodePorts: map[string][]int32{testLocalNodeID: {8080}, testRemoteNodeID2: {8000}, testRemoteNodeID1: {8000}},
+			want:      []string{"node-hpc-1/0/10.0.0.1:8080", "node-hpc-2/1/10.0.0.2:8000 remote", "node-hpc-3/1/10.0.0.3:8000 remote"},
+		},
+		{
+			name:      "unknown nodes are skipped",
+			nodePorts: map[string][]int32{"node-unknown": {8000}, testRemoteNodeID1: {8000}},
+			want:      []string{"node-hpc-2/0/10.0.0.2:8000 remote"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := newTestServer(registry.ServiceConfigSnapshot{})
+
+			loadAssignment := x.makeLoadAssignment("brake_v1_cluster", "brake", testLocalNodeID, test.nodePorts)
+			assert.Equal(t, loadAssignment.ClusterName, "brake_v1_cluster")
+			assert.DeepEqual(t, endpointSummary(loadAssignment), test.want)
+
+			// only the instances on the local node are actively probed, see applyHealthCheck
+			for _, locality := range loadAssignment.Endpoints {
+				for _, e := range locality.LbEndpoints {
+					remote := locality.Locality.Zone != testLocalNodeID
+					assert.Equal(t, e.GetEndpoint().GetHealthCheckConfig().GetDisableActiveHealthCheck(), remote)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	rbacconfig "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// Header that marks the requests of safety-relevant bundles between the nodes. It is only set by the safety egress
+	// listener and removed from all other requests, so that the ingress listener of the called node can prioritize them.
+	priorityHeader      = "x-carisma-priority"
+	priorityHeaderValue = "high"
+
+	// Name of the RBAC filter that only admits safety-relevant bundles to the safety egress listener. It differs from
+	// the name of the RBAC filter of the authorization policies, so that their per-route configuration does not replace it.
+	safetyCallerFilterName = "carisma.filters.http.safety_callers"
+
+	// Circuit breaker thresholds of the QM traffic to safety-relevant bundles, a quarter of the defaults of Envoy.
+	// Declared thresholds that are stricter take precedence.
+	qmMaxConnections     = 256
+	qmMaxPendingRequests = 256
+	qmMaxRequests        = 256
+	qmMaxRetries         = 1
+)
+
+// trafficClass encodes the listeners, route configurations and routing priority of the traffic of one criticality
+// class. All ASIL classes share the safety traffic class, see config.Criticality.
+type trafficClass struct {
+	safetyRelevant      bool
+	ingressListenerName string
+	egressListenerName  string
+	ingressStatPrefix   string
+	egressStatPrefix    string
+	routeName           string
+	localRouteName      string
+	priority            core.RoutingPriority
+}
+
+var (
+	qmTrafficClass = trafficClass{
+		safetyRelevant:      false,
+		ingressListenerName: ingressListenerName,
+		egressListenerName:  egressListenerName,
+		ingressStatPrefix:   ingressStatPrefix,
+		egressStatPrefix:    egressStatPrefix,
+		routeName:           gRPCRouteName,
+		localRouteName:      localGRPCRouteName,
+		priority:            core.RoutingPriority_DEFAULT,
+	}
+
+	safetyTrafficClass = trafficClass{
+		safetyRelevant:      true,
+		ingressListenerName: "safety_ingress_listener",
+		egressListenerName:  "safety_egress_listener",
+		ingressStatPrefix:   "safety_ingress_http",
+		egressStatPrefix:    "safety_egress_http",
+		routeName:           "safety_grpc_route",
+		localRouteName:      "local_safety_grpc_route",
+		priority:            core.RoutingPriority_HIGH,
+	}
+
+	trafficClasses = []trafficClass{qmTrafficClass, safetyTrafficClass}
+)
+
+// ingressPort returns the port remote nodes reach the bundles of the traffic class on.
+func (t trafficClass) ingressPort(cfg *config.Config) int {
+	if t.safetyRelevant {
+		return cfg.SafetyIngressPort
+	}
+
+	return cfg.IngressPort
+}
+
+// egressPort returns the port local bundles of the traffic class send their requests to.
+func (t trafficClass) egressPort(cfg *config.Config) int {
+	if t.safetyRelevant {
+		return cfg.SafetyEgressPort
+	}
+
+	return cfg.EgressPort
+}
+
+// classOf returns the traffic class of the provided criticality class.
+func classOf(criticality config.Criticality) trafficClass {
+	if criticality.SafetyRelevant() {
+		return safetyTrafficClass
+	}
+
+	return qmTrafficClass
+}
+
+// bundleCriticality returns the criticality class declared by the provided bundle, see bundlePolicy.
+func (x *Server) bundleCriticality(bundleID string) config.Criticality {
+	if service := x.declaringService(bundleID, func(s *registry.ServiceConfig) bool { return s.Criticality != "" }); service != nil {
+		return service.Criticality
+	}
+
+	return config.CriticalityQM
+}
+
+// safetyRelevantBundles returns the sorted IDs of all safety-relevant bundles.
+func (x *Server) safetyRelevantBundles() []string {
+	bundleIDs := make(map[string]struct{})
+	for _, services := range x.services {
+		for bundleID := range services {
+			if x.bundleCriticality(bundleID).SafetyRelevant() {
+				bundleIDs[bundleID] = struct{}{}
+			}
+		}
+	}
+
+	ids := maps.Keys(bundleIDs)
+	slices.Sort(ids)
+
+	return ids
+}
+
+// makeSafetyCallerFilter creates an RBAC filter that only admits the requests of safety-relevant bundles, so that QM
+// bundles cannot send their requests at high priority. The bundles are identified by the caller header, which the
+// identity filter of the safety egress listener only sets for the caller tokens of safety-relevant bundles, see
+// makeHTTPListener. Anonymous requests and the requests of QM bundles are thus denied.
+func (x *Server) makeSafetyCallerFilter() (*hcm.HttpFilter, error) {
+	policies := make(map[string]*rbacconfig.Policy)
+
+	// without safety-relevant bundles, all requests are denied
+	if bundleIDs := x.safetyRelevantBundles(); len(bundleIDs) > 0 {
+		principals := make([]*rbacconfig.Principal, len(bundleIDs))
+		for idx, bundleID := range bundleIDs {
+			principals[idx] = x.makeSourcePrincipal(bundleID, false)
+		}
+
+		policies["safety-relevant-callers"] = &rbacconfig.Policy{
+			Permissions: makePathPermissions(nil),
+			Principals:  principals,
+		}
+	}
+
+	rbacConfig, err := anypb.New(&rbac.RBAC{
+		Rules: &rbacconfig.RBAC{
+			Action:   rbacconfig.RBAC_ALLOW,
+			Policies: policies,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       safetyCallerFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rbacConfig},
+	}, nil
+}
+
+// withPriority returns copies of the provided routes that forward the requests at the provided priority. Envoy keeps
+// separate connection pools and circuit breakers per priority, see applyCriticality.
+func withPriority(routes []*route.Route, priority core.RoutingPriority) []*route.Route {
+	prioritized := make([]*route.Route, len(routes))
+	for idx, r := range routes {
+		prioritized[idx] = proto.Clone(r).(*route.Route)
+		prioritized[idx].GetRoute().Priority = priority
+	}
+
+	return prioritized
+}
+
+// prioritizeCallers returns the provided local routes of a safety-relevant bundle twice, the requests of safety-relevant
+// bundles on remote nodes, which carry the priority header, are forwarded at high priority and all others at the
+// default priority.
+func prioritizeCallers(routes []*route.Route) []*route.Route {
+	prioritized := withPriority(routes, core.RoutingPriority_HIGH)
+	for _, r := range prioritized {
+		r.Match.Headers = append(r.Match.Headers, makeHeaderMatcher(priorityHeader, priorityHeaderValue))
+	}
+
+	return append(prioritized, routes...)
+}
+
+// stricter returns the provided threshold, unless it is unset or exceeds the provided limit.
+func stricter(threshold *wrapperspb.UInt32Value, limit uint32) *wrapperspb.UInt32Value {
+	if threshold != nil && threshold.Value < limit {
+		return threshold
+	}
+
+	return wrapperspb.UInt32(limit)
+}
+
+// applyCriticality separates the circuit breakers of the requests of safety-relevant and QM bundles to the provided
+// cluster of a safety-relevant bundle. The requests at high priority are subject to the declared thresholds, the
+// requests at default priority to stricter ones, so that QM bundles cannot exhaust the connections to the bundle.
+func applyCriticality(c *cluster.Cluster, criticality config.Criticality) {
+	if !criticality.SafetyRelevant() {
+		return
+	}
+
+	declared := &cluster.CircuitBreakers_Thresholds{}
+	if c.CircuitBreakers != nil && len(c.CircuitBreakers.Thresholds) > 0 {
+		declared = c.CircuitBreakers.Thresholds[0]
+	}
+
+	high := proto.Clone(declared).(*cluster.CircuitBreakers_Thresholds)
+	high.Priority = core.RoutingPriority_HIGH
+
+	c.CircuitBreakers = &cluster.CircuitBreakers{
+		Thresholds: []*cluster.CircuitBreakers_Thresholds{
+			{
+				Priority:           core.RoutingPriority_DEFAULT,
+				MaxConnections:     stricter(declared.MaxConnections, qmMaxConnections),
+				MaxPendingRequests: stricter(declared.MaxPendingRequests, qmMaxPendingRequests),
+				MaxRequests:        stricter(declared.MaxRequests, qmMaxRequests),
+				MaxRetries:         stricter(declared.MaxRetries, qmMaxRetries),
+			},
+			high,
+		},
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"strings"
+	"testing"
+)
+
+// egressFilters returns the HTTP filters of the egress listener with the provided name.
+func egressFilters(t *testing.T, x *Server, listenerName string) []*hcm.HttpFilter {
+	t.Helper()
+
+	listeners, err := x.makeHTTPListener(testLocalNodeID, config.Default())
+	assert.NilError(t, err)
+
+	for _, res := range listeners {
+		l := res.(*listener.Listener)
+		if l.Name != listenerName {
+			continue
+		}
+
+		manager := &hcm.HttpConnectionManager{}
+		assert.NilError(t, l.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(manager))
+
+		return manager.HttpFilters
+	}
+
+	t.Fatalf("listener %s missing", listenerName)
+
+	return nil
+}
+
+func identityScript(t *testing.T, filter *hcm.HttpFilter) string {
+	t.Helper()
+
+	assert.Equal(t, filter.Name, callerIdentityFilterName)
+
+	luaConfig := &lua.Lua{}
+	assert.NilError(t, filter.GetTypedConfig().UnmarshalTo(luaConfig))
+
+	return luaConfig.DefaultSourceCode.GetInlineString()
+}
+
+func TestSafetyEgressOnlyIdentifiesSafetyRelevantBundles(t *testing.T) {
+	x := newTestServer(registry.ServiceConfigSnapshot{
+		testLocalNodeID: {
+			"brake": {Versions: map[string][]int32{"v1": {8080}}, Criticality: config.CriticalityASILB, CallerTokens: []string{"b1"}},
+			"radio": {Versions: map[string][]int32{"v1": {8081}}, CallerTokens: []string{"r1"}},
+		},
+	})
+
+	script := identityScript(t, egressFilters(t, x, qmTrafficClass.egressListenerName)[0])
+	assert.Assert(t, strings.Contains(script, `["b1"] = "brake"`))
+	assert.Assert(t, strings.Contains(script, `["r1"] = "radio"`))
+
+	filters := egressFilters(t, x, safetyTrafficClass.egressListenerName)
+
+	// the token of the QM bundle is unknown to the safety egress listener, thus its requests are anonymous
+	script = identityScript(t, filters[0])
+	assert.Assert(t, strings.Contains(script, `["b1"] = "brake"`))
+	assert.Assert(t, !strings.Contains(script, "r1"))
+
+	var callerFilter *hcm.HttpFilter
+	for _, f := range filters {
+		if f.Name == safetyCallerFilterName {
+			callerFilter = f
+		}
+	}
+	assert.Assert(t, callerFilter != nil)
+
+	rbacConfig := &rbac.RBAC{}
+	assert.NilError(t, callerFilter.GetTypedConfig().UnmarshalTo(rbacConfig))
+
+	principals := rbacConfig.Rules.Policies["safety-relevant-callers"].Principals
+	assert.Equal(t, len(principals), 1)
+	assert.Equal(t, principals[0].GetHeader().GetName(), callerHeader)
+	assert.Equal(t, principals[0].GetHeader().GetStringMatch().GetExact(), "brake")
+}
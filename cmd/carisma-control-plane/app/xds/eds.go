@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	"fmt"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/structpb"
+	"net"
+	"time"
+)
+
+const (
+	// Metadata namespace Envoy matches the transport socket matches of a cluster against.
+	transportSocketMatchKey = "envoy.transport_socket_match"
+	// Field of the transport socket match metadata that marks endpoints on remote nodes.
+	remoteEndpointMatchField = "remote"
+
+	// Maximum duration of resolving the address of a node.
+	resolveTimeout = 2 * time.Second
+)
+
+// resolveIPv4 resolves the provided host to an IPv4 address, as Envoy does not resolve hostnames of endpoints provided via EDS.
+func resolveIPv4(ctx context.Context, host string) (string, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip4", host)
+	if err != nil {
+		return "", err
+	}
+
+	if len(ips) == 0 {
+		return "", fmt.Errorf("no IPv4 address found for host %s", host)
+	}
+
+	return ips[0].String(), nil
+}
+
+// resolveNodes resolves the addresses of the provided nodes once they register, rather than with every snapshot. A node
+// that cannot be resolved keeps its last known address. The caller must not hold the lock, as resolving takes up to
+// resolveTimeout per node.
+func (x *Server) resolveNodes(ctx context.Context, nodes registry.NodeSnapshot) map[string]string {
+	x.mu.RLock()
+	known := maps.Clone(x.addresses)
+	x.mu.RUnlock()
+
+	addresses := make(map[string]string, len(nodes))
+	for nodeID, node := range nodes {
+		address, err := resolveIPv4(ctx, node.Addr.Host)
+		if err == nil {
+			addresses[nodeID] = address
+
+			continue
+		}
+
+		e := logging.DefaultLogger.Error().Err(err).
+			Str("Node", nodeID).
+			Str("Host", node.Addr.Host)
+
+		if lastAddress, ok := known[nodeID]; ok {
+			addresses[nodeID] = lastAddress
+			e = e.Str("Address", lastAddress)
+		}
+
+		e.Msg("could not resolve node address")
+	}
+
+	return addresses
+}
+
+func (x *Server) makeEndpoints(nodeID, bundleID string, ports []int32) ([]*endpoint.LbEndpoint, error) {
+	nodeAddress, ok := x.addresses[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("no address of node: %s", nodeID)
+	}
+
+	endpoints := make([]*endpoint.LbEndpoint, 0)
+	for _, port := range ports {
+		lbEndpoint := endpoint.LbEndpoint{
+			HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+				Endpoint: &endpoint.Endpoint{
+					Address: &core.Address{
+						Address: &core.Address_SocketAddress{
+							SocketAddress: &core.SocketAddress{
+								Protocol: core.SocketAddress_TCP,
+								Address:  nodeAddress,
+								PortSpecifier: &core.SocketAddress_PortValue{
+									PortValue: uint32(port),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		e := logging.DefaultLogger.Debug().
+			Str("Node", nodeID).
+			Str("Bundle", bundleID).
+			Str("Address", nodeAddress).
+			Int("Port", int(port))
+
+		e.Msg("Registering service endpoint")
+
+		endpoints = append(endpoints, &lbEndpoint)
+	}
+
+	return endpoints, nil
+}
+
+// makeRemoteEndpointMetadata marks endpoints on remote nodes, so that clusters with instances on several nodes only
+// use mutual TLS towards the ingress listeners of remote nodes, see makeRemoteTransportSocketMatches.
+func makeRemoteEndpointMetadata() *core.Metadata {
+	return &core.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			transportSocketMatchKey: {
+				Fields: map[string]*structpb.Value{
+					remoteEndpointMatchField: structpb.NewBoolValue(true),
+				},
+			},
+		},
+	}
+}
+
+// makeLoadAssignment groups the instances of the provided cluster into one locality per node. The instances on the
+// provided local node form priority 0 and the instances on the remaining nodes the next lower priority, so that Envoy
+// prefers instances on its own node and fails over to other nodes once the local instances become unhealthy.
+func (x *Server) makeLoadAssignment(clusterID, bundleID, localNodeID string, nodePorts map[string][]int32) *endpoint.ClusterLoadAssignment {
+	nodeIDs := maps.Keys(nodePorts)
+	slices.Sort(nodeIDs)
+
+	// Envoy requires the priorities to be contiguous, starting at 0
+	var remotePriority uint32
+	if _, ok := nodePorts[localNodeID]; ok {
+		remotePriority = 1
+	}
+
+	endpoints := make([]*endpoint.LocalityLbEndpoints, 0)
+	for _, nodeID := range nodeIDs {
+		nodeEndpoints, err := x.makeEndpoints(nodeID, bundleID, nodePorts[nodeID])
+		if err != nil {
+			// An unresolvable node must not prevent the remaining snapshot from being served, thus the node is skipped.
+			logging.DefaultLogger.Error().Err(err).
+				Str("Node", nodeID).
+				Str("Cluster", clusterID).
+				Msg("could not create endpoints")
+
+			continue
+		}
+
+		localityEndpoints := &endpoint.LocalityLbEndpoints{
+			Locality:    &core.Locality{Zone: nodeID},
+			LbEndpoints: nodeEndpoints,
+		}
+
+		if nodeID != localNodeID {
+			localityEndpoints.Priority = remotePriority
+
+			// the instances behind the ingress listener of a remote node are probed by the Envoy on that node
+			for _, e := range nodeEndpoints {
+				e.Metadata = makeRemoteEndpointMetadata()
+				e.GetEndpoint().HealthCheckConfig = &endpoint.Endpoint_HealthCheckConfig{DisableActiveHealthCheck: true}
+			}
+		}
+
+		endpoints = append(endpoints, localityEndpoints)
+	}
+
+	return &endpoint.ClusterLoadAssignment{
+		ClusterName: clusterID,
+		Endpoints:   endpoints,
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+// testResourceTypes are the resource types served to every node without mutual TLS.
+var testResourceTypes = []resource.Type{resource.ClusterType, resource.EndpointType, resource.RouteType, resource.ListenerType}
+
+// newTestServices creates the services of the test server, in which the brake bundle runs on the local node and the
+// first remote node.
+func newTestServices() registry.ServiceConfigSnapshot {
+	return registry.ServiceConfigSnapshot{
+		testLocalNodeID:   {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+		testRemoteNodeID1: {"brake": {Versions: map[string][]int32{"v1": {8080}}}},
+	}
+}
+
+func TestGenerateSnapshotsServesEndpointsViaEDS(t *testing.T) {
+	// the cluster of the trace collector is resolved via DNS
+	cfg := config.Default()
+	cfg.EnableTracing = false
+
+	x := newTestServer(newTestServices())
+	assert.NilError(t, x.generateSnapshots(context.Background(), cfg))
+
+	snapshot, err := x.cache.GetSnapshot(testLocalNodeID)
+	assert.NilError(t, err)
+
+	clusters := snapshot.GetResources(resource.ClusterType)
+	loadAssignments := snapshot.GetResources(resource.EndpointType)
+	assert.Equal(t, len(clusters), 2)
+	assert.Equal(t, len(loadAssignments), len(clusters))
+
+	// the endpoints of every cluster are served separately, by the load assignment of the same name
+	for name, r := range clusters {
+		c := r.(*cluster.Cluster)
+		assert.Equal(t, c.GetType(), cluster.Cluster_EDS)
+		assert.Equal(t, c.EdsClusterConfig.ServiceName, name)
+		assert.Assert(t, c.EdsClusterConfig.EdsConfig.GetAds() != nil)
+
+		loadAssignment, ok := loadAssignments[name]
+		assert.Assert(t, ok, name)
+		assert.Equal(t, loadAssignment.(*endpoint.ClusterLoadAssignment).ClusterName, name)
+	}
+
+	assert.DeepEqual(t, endpointSummary(loadAssignments["brake_v1_cluster"].(*endpoint.ClusterLoadAssignment)),
+		[]string{"node-hpc-1/0/10.0.0.1:8080", "node-hpc-2/1/10.0.0.2:8000 remote"})
+}
+
+func TestGenerateSnapshotsVersionsResourceTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		update      func(x *Server)
+		wantChanged []resource.Type
+	}{
+		{
+			name:        "an unchanged registry keeps all versions",
+			update:      func(x *Server) {},
+			wantChanged: []resource.Type{},
+		},
+		{
+			name: "a moved instance only changes the endpoints",
+			update: func(x *Server) {
+				x.services[testLocalNodeID]["brake"].Versions["v1"] = []int32{8081}
+			},
+			wantChanged: []resource.Type{resource.EndpointType},
+		},
+		{
+			name: "a dead remote node only changes the endpoints",
+			update: func(x *Server) {
+				x.nodeHealth[testRemoteNodeID1] = registry.NodeHealthDead
+			},
+			wantChanged: []resource.Type{resource.EndpointType},
+		},
+		{
+			name: "a new bundle changes the clusters, endpoints and routes",
+			update: func(x *Server) {
+				x.services[testRemoteNodeID2] = map[string]*registry.ServiceConfig{
+					"media": {Versions: map[string][]int32{"v1": {8080}}},
+				}
+			},
+			wantChanged: []resource.Type{resource.ClusterType, resource.EndpointType, resource.RouteType},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := newTestServer(newTestServices())
+			cfg := config.Default()
+
+			assert.NilError(t, x.generateSnapshots(context.Background(), cfg))
+			previous, err := x.cache.GetSnapshot(testLocalNodeID)
+			assert.NilError(t, err)
+
+			test.update(x)
+
+			assert.NilError(t, x.generateSnapshots(context.Background(), cfg))
+			snapshot, err := x.cache.GetSnapshot(testLocalNodeID)
+			assert.NilError(t, err)
+
+			changed := make([]resource.Type, 0)
+			for _, typ := range testResourceTypes {
+				if snapshot.GetVersion(typ) != previous.GetVersion(typ) {
+					assert.Equal(t, snapshot.GetVersion(typ), "1.0", typ)
+
+					changed = append(changed, typ)
+				}
+			}
+
+			assert.DeepEqual(t, changed, test.wantChanged)
+		})
+	}
+}
+
+func TestResolveNodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		known map[string]string
+		hosts map[string]string
+		want  map[string]string
+	}{
+		{
+			name:  "IP addresses are taken as they are",
+			hosts: map[string]string{testLocalNodeID: "10.0.0.1", testRemoteNodeID1: "10.0.0.2"},
+			want:  map[string]string{testLocalNodeID: "10.0.0.1", testRemoteNodeID1: "10.0.0.2"},
+		},
+		{
+			name:  "changed addresses replace the known addresses",
+			known: map[string]string{testRemoteNodeID1: "10.0.0.2"},
+			hosts: map[string]string{testRemoteNodeID1: "10.0.0.12"},
+			want:  map[string]string{testRemoteNodeID1: "10.0.0.12"},
+		},
+		{
+			name:  "unresolvable nodes keep their last known address",
+			known: map[string]string{testRemoteNodeID1: "10.0.0.2"},
+			hosts: map[string]string{testRemoteNodeID1: "hpc-2.invalid"},
+			want:  map[string]string{testRemoteNodeID1: "10.0.0.2"},
+		},
+		{
+			name:  "unresolvable nodes without known address are skipped",
+			hosts: map[string]string{testLocalNodeID: "10.0.0.1", testRemoteNodeID1: "hpc-2.invalid"},
+			want:  map[string]string{testLocalNodeID: "10.0.0.1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := NewServer(nil)
+			for nodeID, address := range test.known {
+				x.addresses[nodeID] = address
+			}
+
+			nodes := make(registry.NodeSnapshot, len(test.hosts))
+			for nodeID, host := range test.hosts {
+				nodes[nodeID] = &registry.Node{Addr: &registry.NodeAddr{Host: host, Port: 8000}}
+			}
+
+			assert.DeepEqual(t, x.resolveNodes(context.Background(), nodes), test.want)
+		})
+	}
+}
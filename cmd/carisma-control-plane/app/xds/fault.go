@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	faultcommon "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/common/fault/v3"
+	fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"strings"
+	"time"
+)
+
+// makeFaultFilter creates a fault filter that does not inject any fault by itself. The faults are injected by the
+// per-route configuration of the routes to the affected bundles, see injectFaults.
+func makeFaultFilter() (*hcm.HttpFilter, error) {
+	faultConfig, err := anypb.New(&fault.HTTPFault{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       wellknown.Fault,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: faultConfig},
+	}, nil
+}
+
+func makePercent(percent uint32) *typev3.FractionalPercent {
+	return &typev3.FractionalPercent{Numerator: percent, Denominator: typev3.FractionalPercent_HUNDRED}
+}
+
+// makeFault creates the per-route configuration of the fault filter that injects the provided fault.
+func makeFault(f registry.Fault) (*anypb.Any, error) {
+	httpFault := &fault.HTTPFault{}
+
+	if f.DelayMs > 0 && f.DelayPercent > 0 {
+		httpFault.Delay = &faultcommon.FaultDelay{
+			FaultDelaySecifier: &faultcommon.FaultDelay_FixedDelay{FixedDelay: millis(f.DelayMs)},
+			Percentage:         makePercent(f.DelayPercent),
+		}
+	}
+
+	if f.AbortGRPCStatus > 0 && f.AbortPercent > 0 {
+		httpFault.Abort = &fault.FaultAbort{
+			ErrorType:  &fault.FaultAbort_GrpcStatus{GrpcStatus: f.AbortGRPCStatus},
+			Percentage: makePercent(f.AbortPercent),
+		}
+	}
+
+	return anypb.New(httpFault)
+}
+
+// bundleFaults returns the active faults of the provided bundle, the faults with the longest path prefix first.
+func (x *Server) bundleFaults(bundleID string) []registry.Fault {
+	now := time.Now()
+
+	faults := make([]registry.Fault, 0)
+	for _, f := range x.faults {
+		// expired faults are removed by the fault server, but must not be distributed in the meantime
+		if f.BundleID == bundleID && f.Expires.After(now) {
+			faults = append(faults, f)
+		}
+	}
+
+	slices.SortFunc(faults, func(a, b registry.Fault) int {
+		if c := len(b.PathPrefix) - len(a.PathPrefix); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.PathPrefix, b.PathPrefix)
+	})
+
+	return faults
+}
+
+func injectFault(r *route.Route, f registry.Fault) (*route.Route, error) {
+	faultConfig, err := makeFault(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.TypedPerFilterConfig == nil {
+		r.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	r.TypedPerFilterConfig[wellknown.Fault] = faultConfig
+
+	return r, nil
+}
+
+// injectFaults injects the active faults of the provided bundle into the provided route. A fault whose path prefix is
+// more specific than the prefix of the route receives a copy of the route that only matches its path prefix. All other
+// requests matched by the route are affected by the most specific fault that covers the whole route, if any.
+func (x *Server) injectFaults(bundleID string, r *route.Route) ([]*route.Route, error) {
+	routes := make([]*route.Route, 0, 1)
+	prefix, isPrefix := r.Match.PathSpecifier.(*route.RouteMatch_Prefix)
+
+	var covering *registry.Fault
+	for _, f := range x.bundleFaults(bundleID) {
+		if f.PathPrefix == "" || (isPrefix && strings.HasPrefix(prefix.Prefix, f.PathPrefix)) {
+			if covering == nil {
+				covering = &f
+			}
+
+			continue
+		}
+
+		if !isPrefix || !strings.HasPrefix(f.PathPrefix, prefix.Prefix) {
+			continue
+		}
+
+		faultRoute := proto.Clone(r).(*route.Route)
+		faultRoute.Match.PathSpecifier = &route.RouteMatch_Prefix{Prefix: f.PathPrefix}
+
+		faultRoute, err := injectFault(faultRoute, f)
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, faultRoute)
+	}
+
+	if covering != nil {
+		if _, err := injectFault(r, *covering); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(routes, r), nil
+}
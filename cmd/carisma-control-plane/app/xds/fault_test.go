@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	fault "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"testing"
+	"time"
+)
+
+// faultSummary encodes the provided routes as "<prefix>" followed by the delay and abort injected into them, if any.
+func faultSummary(t *testing.T, routes []*route.Route) []string {
+	summary := make([]string, 0, len(routes))
+	for _, r := range routes {
+		s := r.Match.GetPrefix()
+
+		if faultConfig, ok := r.TypedPerFilterConfig[wellknown.Fault]; ok {
+			httpFault := &fault.HTTPFault{}
+			assert.NilError(t, faultConfig.UnmarshalTo(httpFault))
+
+			if httpFault.Delay != nil {
+				s += fmt.Sprintf(" delay=%v/%v%%", httpFault.Delay.GetFixedDelay().AsDuration(), httpFault.Delay.Percentage.Numerator)
+			}
+
+			if httpFault.Abort != nil {
+				s += fmt.Sprintf(" abort=%v/%v%%", httpFault.Abort.GetGrpcStatus(), httpFault.Abort.Percentage.Numerator)
+			}
+		}
+
+		summary = append(summary, s)
+	}
+
+	return summary
+}
+
+func TestInjectFaults(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		faults []registry.Fault
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "no faults",
+			prefix: "/brake",
+			want:   []string{"/brake"},
+		},
+		{
+			name: "faults of other bundles and expired faults are ignored",
+			faults: []registry.Fault{
+				{BundleID: "dashboard", AbortGRPCStatus: 2, AbortPercent: 100, Expires: expires},
+				{BundleID: "brake", AbortGRPCStatus: 2, AbortPercent: 100, Expires: time.Now().Add(-time.Minute)},
+			},
+			prefix: "/brake",
+			want:   []string{"/brake"},
+		},
+		{
+			name:   "a fault without path prefix applies to the original route",
+			faults: []registry.Fault{{BundleID: "brake", AbortGRPCStatus: 2, AbortPercent: 100, Expires: expires}},
+			prefix: "/brake.v1.Brake/Apply",
+			want:   []string{"/brake.v1.Brake/Apply abort=2/100%"},
+		},
+		{
+			name: "a more specific fault receives a copy of the route",
+			faults: []registry.Fault{
+				{BundleID: "brake", PathPrefix: "/brake.v1.Brake/Apply", DelayMs: 100, DelayPercent: 50, Expires: expires},
+			},
+			prefix: "/brake.v1",
+			want:   []string{"/brake.v1.Brake/Apply delay=100ms/50%", "/brake.v1"},
+		},
+		{
+			name: "the most specific covering fault applies to the remaining requests",
+			faults: []registry.Fault{
+				{BundleID: "brake", PathPrefix: "/brake.v1.Brake/Apply", DelayMs: 100, DelayPercent: 50, Expires: expires},
+				{BundleID: "brake", PathPrefix: "/brake", AbortGRPCStatus: 14, AbortPercent: 10, Expires: expires},
+				{BundleID: "brake", AbortGRPCStatus: 2, AbortPercent: 100, Expires: expires},
+				{BundleID: "brake", PathPrefix: "/dashboard", AbortGRPCStatus: 2, AbortPercent: 100, Expires: expires},
+			},
+			prefix: "/brake.v1",
+			want:   []string{"/brake.v1.Brake/Apply delay=100ms/50%", "/brake.v1 abort=14/10%"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := newTestServer(registry.ServiceConfigSnapshot{})
+			for i, f := range test.faults {
+				f.ID = fmt.Sprint(i)
+				x.faults[f.ID] = f
+			}
+
+			routes, err := x.injectFaults("brake", &route.Route{
+				Name:  "brake",
+				Match: &route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: test.prefix}},
+			})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, faultSummary(t, routes), test.want)
+
+			for _, r := range routes {
+				assert.Equal(t, r.Name, "brake")
+			}
+		})
+	}
+}
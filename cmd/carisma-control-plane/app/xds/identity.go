@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net"
+	"time"
+)
+
+const (
+	errorMsgUnknownNode     = "node ID not registered"
+	errorMsgPeerMismatch    = "peer address does not match registered host of node"
+	errorMsgNodeIDChanged   = "node ID changed within stream"
+	errorMsgUnknownPeerAddr = "peer address unknown"
+
+	// Maximum time to resolve the registered host of a node.
+	hostLookupTimeout = 2 * time.Second
+)
+
+// peerIP returns the IP address of the provided peer address, if it is an IP-based address.
+func peerIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	}
+
+	return nil
+}
+
+// hostMatches checks whether the provided host, which is either an IP address or a hostname, refers to the provided IP.
+func hostMatches(ctx context.Context, host string, ip net.IP) bool {
+	if hostIP := net.ParseIP(host); hostIP != nil {
+		return hostIP.Equal(ip)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hostLookupTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return false
+	}
+
+	for _, addr := range addrs {
+		if addr.IP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateNode checks whether the Envoy that connected from the provided peer address may receive the snapshot of the
+// provided node, i.e., whether the node is registered and the peer address matches its registered host. The Envoy of
+// the node that runs the control plane may also connect via loopback.
+func (x *Server) validateNode(ctx context.Context, cfg *config.Config, nodeID string, addr net.Addr) error {
+	x.mu.RLock()
+	node, ok := x.nodes[nodeID]
+	x.mu.RUnlock()
+
+	if !ok {
+		return status.Error(codes.PermissionDenied, errorMsgUnknownNode)
+	}
+
+	if !cfg.EnableXDSPeerValidation {
+		return nil
+	}
+
+	ip := peerIP(addr)
+	if ip == nil {
+		return status.Error(codes.PermissionDenied, errorMsgUnknownPeerAddr)
+	}
+
+	if ip.IsLoopback() && node.Hostname == cfg.NodeHostname {
+		return nil
+	}
+
+	if node.Addr == nil || !hostMatches(ctx, node.Addr.Host, ip) {
+		return status.Error(codes.PermissionDenied, errorMsgPeerMismatch)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+)
+
+// Placeholder of the caller tokens in the introspection.
+const redactedCallerToken = "REDACTED"
+
+// Resource types included in the introspection, keyed by the name they are presented with.
+var introspectedTypes = map[string]resource.Type{
+	"clusters":  resource.ClusterType,
+	"endpoints": resource.EndpointType,
+	"routes":    resource.RouteType,
+	"listeners": resource.ListenerType,
+	"secrets":   resource.SecretType,
+}
+
+// resourceDump encodes the resources of one type in the snapshot of a node and whether the node accepted them.
+type resourceDump struct {
+	Version   string            `json:"version"`
+	Resources []json.RawMessage `json:"resources,omitempty"`
+	Status    *ResourceStatus   `json:"status,omitempty"`
+}
+
+type nodeDump struct {
+	NodeID    string                  `json:"nodeID"`
+	Resources map[string]resourceDump `json:"resources"`
+}
+
+type snapshotDump struct {
+	Version string     `json:"version"`
+	Nodes   []nodeDump `json:"nodes"`
+}
+
+// dumpNode encodes the current snapshot of the provided node. The private keys distributed via SDS are never included,
+// thus only the version and status of the secrets are presented. The caller tokens of the bundles on the node are
+// redacted from the listeners, see makeCallerIdentityFilter.
+func (x *Server) dumpNode(nodeID string) (nodeDump, error) {
+	snapshot, err := x.cache.GetSnapshot(nodeID)
+	if err != nil {
+		return nodeDump{}, err
+	}
+
+	status := x.callbacks.nodeStatus(nodeID)
+
+	dump := nodeDump{NodeID: nodeID, Resources: make(map[string]resourceDump, len(introspectedTypes))}
+	for name, typ := range introspectedTypes {
+		d := resourceDump{Version: snapshot.GetVersion(typ)}
+
+		if s, ok := status[typ]; ok {
+			d.Status = &s
+		}
+
+		if typ != resource.SecretType {
+			resources := snapshot.GetResources(typ)
+
+			names := maps.Keys(resources)
+			slices.Sort(names)
+
+			for _, resourceName := range names {
+				b, err := protojson.Marshal(resources[resourceName].(proto.Message))
+				if err != nil {
+					return nodeDump{}, err
+				}
+
+				for token := range x.localCallers(nodeID) {
+					b = bytes.ReplaceAll(b, []byte(token), []byte(redactedCallerToken))
+				}
+
+				d.Resources = append(d.Resources, b)
+			}
+		}
+
+		dump.Resources[name] = d
+	}
+
+	return dump, nil
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(v)
+	logging.LogErr(err)
+}
+
+func (x *Server) handleSnapshots(w http.ResponseWriter, _ *http.Request) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	nodeIDs := maps.Keys(x.nodes)
+	slices.Sort(nodeIDs)
+
+	dump := snapshotDump{Version: fmt.Sprintf("%v.0", x.snapshotVersion), Nodes: make([]nodeDump, 0, len(nodeIDs))}
+	for _, nodeID := range nodeIDs {
+		d, err := x.dumpNode(nodeID)
+		if err != nil {
+			// the node has not received a snapshot yet
+			continue
+		}
+
+		dump.Nodes = append(dump.Nodes, d)
+	}
+
+	writeJSON(w, dump)
+}
+
+func (x *Server) handleNodeSnapshot(w http.ResponseWriter, r *http.Request) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	d, err := x.dumpNode(r.PathValue("nodeID"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	writeJSON(w, d)
+}
+
+// RegisterHandlers registers the read-only HTTP API that presents the current snapshot version and the snapshot of every
+// node as JSON, together with the versions each node ACKed or NACKed.
+func (x *Server) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /snapshots", x.handleSnapshots)
+	mux.HandleFunc("GET /snapshots/{nodeID}", x.handleNodeSnapshot)
+}
@@ -0,0 +1,415 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	cncfcore "github.com/cncf/xds/go/xds/core/v3"
+	cncfmatcher "github.com/cncf/xds/go/xds/type/matcher/v3"
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	udp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
+	network "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/common_inputs/network/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	udpProxyFilterName = "envoy.filters.udp_listener.udp_proxy"
+	udpProxyRouteName  = "envoy.extensions.filters.udp.udp_proxy.v3.Route"
+	sourceIPInputName  = "envoy.matching.inputs.source_ip"
+	ipMatcherName      = "envoy.matching.custom_matchers.trie_matcher"
+)
+
+// servicePortInstances encodes where the instances of a TCP or UDP service port of a bundle run from the perspective of
+// a node. The instances of all versions of the bundle are treated alike.
+type servicePortInstances struct {
+	bundleID      string
+	port          config.ServicePort
+	localPorts    []int32
+	remoteNodeIDs []string
+}
+
+func (s *servicePortInstances) key() string {
+	return fmt.Sprintf("%v_%v_%v", s.bundleID, s.port.Name, s.port.Protocol)
+}
+
+func (s *servicePortInstances) clusterName(isLocal bool) string {
+	if isLocal {
+		return fmt.Sprintf("local_%v_cluster", s.key())
+	}
+
+	return fmt.Sprintf("%v_cluster", s.key())
+}
+
+func ingressPort(cfg *config.Config, port config.ServicePort) uint32 {
+	return port.Port + uint32(cfg.ServiceIngressPortOffset)
+}
+
+// bundleServicePorts returns the TCP and UDP service ports declared by the provided bundle, see bundlePolicy.
+func (x *Server) bundleServicePorts(bundleID string) []config.ServicePort {
+	if service := x.declaringService(bundleID, func(s *registry.ServiceConfig) bool { return len(s.Ports) > 0 }); service != nil {
+		return service.Ports
+	}
+
+	return nil
+}
+
+// servicePorts collects the instances of all TCP and UDP service ports from the perspective of the provided node.
+// Service ports outside the configured port range and service ports whose port is already taken by another bundle
+// are skipped.
+func (x *Server) servicePorts(localNodeID string, cfg *config.Config) []*servicePortInstances {
+	bundleIDs := make(map[string]struct{})
+	for _, services := range x.services {
+		for bundleID := range services {
+			bundleIDs[bundleID] = struct{}{}
+		}
+	}
+
+	sortedBundleIDs := maps.Keys(bundleIDs)
+	slices.Sort(sortedBundleIDs)
+
+	nodeIDs := maps.Keys(x.services)
+	slices.Sort(nodeIDs)
+
+	servicePorts := make([]*servicePortInstances, 0)
+	takenPorts := make(map[string]string)
+
+	for _, bundleID := range sortedBundleIDs {
+		for _, port := range x.bundleServicePorts(bundleID) {
+			if port.Port < uint32(cfg.ServicePortMin) || port.Port > uint32(cfg.ServicePortMax) {
+				logging.DefaultLogger.Error().
+					Str("Bundle", bundleID).
+					Str("Service-Port", port.Name).
+					Uint32("Port", port.Port).
+					Msg("service port outside of configured port range")
+
+				continue
+			}
+
+			portKey := fmt.Sprintf("%v/%v", port.Port, port.Protocol)
+			if owner, ok := takenPorts[portKey]; ok {
+				logging.DefaultLogger.Error().
+					Str("Bundle", bundleID).
+					Str("Owner", owner).
+					Str("Service-Port", port.Name).
+					Uint32("Port", port.Port).
+					Msg("service port already taken by another bundle")
+
+				continue
+			}
+
+			instances := &servicePortInstances{bundleID: bundleID, port: port}
+
+			for _, nodeID := range nodeIDs {
+				// do not route to nodes that are considered dead
+				if nodeID != localNodeID && x.nodeHealth[nodeID] == registry.NodeHealthDead {
+					continue
+				}
+
+				service, ok := x.services[nodeID][bundleID]
+				if !ok || len(service.PortVersions[port.Name]) == 0 {
+					continue
+				}
+
+				if nodeID == localNodeID {
+					for _, ports := range service.PortVersions[port.Name] {
+						instances.localPorts = append(instances.localPorts, ports...)
+					}
+					slices.Sort(instances.localPorts)
+				} else {
+					instances.remoteNodeIDs = append(instances.remoteNodeIDs, nodeID)
+				}
+			}
+
+			if len(instances.localPorts) == 0 && len(instances.remoteNodeIDs) == 0 {
+				continue
+			}
+
+			takenPorts[portKey] = bundleID
+			servicePorts = append(servicePorts, instances)
+		}
+	}
+
+	return servicePorts
+}
+
+func (x *Server) makeServicePortCluster(clusterID string, policy *config.TrafficPolicy) *cluster.Cluster {
+	logging.DefaultLogger.Debug().
+		Str("Cluster", clusterID).
+		Msg("Registering service port cluster")
+
+	c := &cluster.Cluster{
+		Name:                 clusterID,
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_EDS},
+		EdsClusterConfig: &cluster.Cluster_EdsClusterConfig{
+			EdsConfig:   getConfigSource(),
+			ServiceName: clusterID,
+		},
+	}
+	applyClusterPolicy(c, policy)
+
+	return c
+}
+
+// makeServicePortClusters creates the clusters of the provided service ports, see makeClusters. Remote instances are
+// reached via the ingress listener of the service port on their node.
+func (x *Server) makeServicePortClusters(localNodeID string, cfg *config.Config, servicePorts []*servicePortInstances) ([]types.Resource, []types.Resource) {
+	clusters := make([]types.Resource, 0)
+	loadAssignments := make([]types.Resource, 0)
+
+	for _, s := range servicePorts {
+		policy := x.bundlePolicy(s.bundleID)
+		nodePorts := make(map[string][]int32, len(s.remoteNodeIDs)+1)
+
+		if len(s.localPorts) > 0 {
+			clusterID := s.clusterName(true)
+			nodePorts[localNodeID] = s.localPorts
+
+			clusters = append(clusters, x.makeServicePortCluster(clusterID, policy))
+			loadAssignments = append(loadAssignments, x.makeLoadAssignment(clusterID, s.bundleID, localNodeID, map[string][]int32{
+				localNodeID: s.localPorts,
+			}))
+		}
+
+		for _, nodeID := range s.remoteNodeIDs {
+			nodePorts[nodeID] = []int32{int32(ingressPort(cfg, s.port))}
+		}
+
+		clusterID := s.clusterName(false)
+
+		c := x.makeServicePortCluster(clusterID, policy)
+
+		// Envoy does not support DTLS, thus UDP traffic between the nodes is not protected
+		if x.mTLSEnabled() && s.port.Protocol == config.TransportProtocolTCP {
+			c.TransportSocketMatches = makeRemoteTransportSocketMatches(nil)
+		}
+		applyOutlierDetection(c, policy)
+
+		clusters = append(clusters, c)
+		loadAssignments = append(loadAssignments, x.makeLoadAssignment(clusterID, s.bundleID, localNodeID, nodePorts))
+	}
+
+	return clusters, loadAssignments
+}
+
+func makeTCPProxyFilterChain(statPrefix, clusterID string) (*listener.FilterChain, error) {
+	tcpProxy, err := anypb.New(&tcp.TcpProxy{
+		StatPrefix: statPrefix,
+		ClusterSpecifier: &tcp.TcpProxy_Cluster{
+			Cluster: clusterID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.FilterChain{
+		Filters: []*listener.Filter{{
+			Name:       wellknown.TCPProxy,
+			ConfigType: &listener.Filter_TypedConfig{TypedConfig: tcpProxy},
+		}},
+	}, nil
+}
+
+// remoteNodeAddresses returns the addresses of all registered nodes except the provided local node. Nodes whose
+// address has never been resolved are skipped.
+func (x *Server) remoteNodeAddresses(localNodeID string) []string {
+	nodeIDs := maps.Keys(x.nodes)
+	slices.Sort(nodeIDs)
+
+	addresses := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID == localNodeID {
+			continue
+		}
+
+		if address, ok := x.addresses[nodeID]; ok {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+func makeUDPProxyListenerFilter(statPrefix, clusterID string, sources []string) (*listener.ListenerFilter, error) {
+	route, err := anypb.New(&udp.Route{Cluster: clusterID})
+	if err != nil {
+		return nil, err
+	}
+
+	onMatch := &cncfmatcher.Matcher_OnMatch{
+		OnMatch: &cncfmatcher.Matcher_OnMatch_Action{
+			Action: &cncfcore.TypedExtensionConfig{
+				Name:        udpProxyRouteName,
+				TypedConfig: route,
+			},
+		},
+	}
+
+	matcher := &cncfmatcher.Matcher{OnNoMatch: onMatch}
+
+	// datagrams from other sources than the provided ones match no route and are dropped
+	if sources != nil {
+		sourceIPInput, err := anypb.New(&network.SourceIPInput{})
+		if err != nil {
+			return nil, err
+		}
+
+		ranges := make([]*cncfcore.CidrRange, 0, len(sources))
+		for _, source := range sources {
+			ranges = append(ranges, &cncfcore.CidrRange{AddressPrefix: source, PrefixLen: wrapperspb.UInt32(32)})
+		}
+
+		ipMatcher, err := anypb.New(&cncfmatcher.IPMatcher{
+			RangeMatchers: []*cncfmatcher.IPMatcher_IPRangeMatcher{{Ranges: ranges, OnMatch: onMatch}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		matcher = &cncfmatcher.Matcher{
+			MatcherType: &cncfmatcher.Matcher_MatcherTree_{
+				MatcherTree: &cncfmatcher.Matcher_MatcherTree{
+					Input: &cncfcore.TypedExtensionConfig{Name: sourceIPInputName, TypedConfig: sourceIPInput},
+					TreeType: &cncfmatcher.Matcher_MatcherTree_CustomMatch{
+						CustomMatch: &cncfcore.TypedExtensionConfig{Name: ipMatcherName, TypedConfig: ipMatcher},
+					},
+				},
+			},
+		}
+	}
+
+	udpProxy, err := anypb.New(&udp.UdpProxyConfig{
+		StatPrefix:     statPrefix,
+		RouteSpecifier: &udp.UdpProxyConfig_Matcher{Matcher: matcher},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.ListenerFilter{
+		Name:       udpProxyFilterName,
+		ConfigType: &listener.ListenerFilter_TypedConfig{TypedConfig: udpProxy},
+	}, nil
+}
+
+// makeServicePortListener creates a listener that forwards the traffic received at the provided port to the provided
+// cluster. If sources are provided, the listener only accepts traffic from these addresses.
+func makeServicePortListener(name string, protocol config.TransportProtocol, port uint32, clusterID string,
+	direction core.TrafficDirection, sources []string) (*listener.Listener, error) {
+	l := &listener.Listener{
+		Name: name,
+		Address: &core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Protocol: core.SocketAddress_TCP,
+					Address:  "0.0.0.0",
+					PortSpecifier: &core.SocketAddress_PortValue{
+						PortValue: port,
+					},
+				},
+			},
+		},
+		TrafficDirection: direction,
+	}
+
+	if protocol == config.TransportProtocolUDP {
+		l.Address.GetSocketAddress().Protocol = core.SocketAddress_UDP
+
+		udpProxy, err := makeUDPProxyListenerFilter(name, clusterID, sources)
+		if err != nil {
+			return nil, err
+		}
+
+		l.ListenerFilters = []*listener.ListenerFilter{udpProxy}
+
+		return l, nil
+	}
+
+	filterChain, err := makeTCPProxyFilterChain(name, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// connections from other sources than the provided ones match no filter chain and are rejected
+	if sources != nil {
+		ranges := make([]*core.CidrRange, 0, len(sources))
+		for _, source := range sources {
+			ranges = append(ranges, &core.CidrRange{AddressPrefix: source, PrefixLen: wrapperspb.UInt32(32)})
+		}
+
+		filterChain.FilterChainMatch = &listener.FilterChainMatch{SourcePrefixRanges: ranges}
+	}
+
+	l.FilterChains = []*listener.FilterChain{filterChain}
+
+	return l, nil
+}
+
+// makeServicePortListeners creates an egress listener for every service port and an ingress listener for every service
+// port with local instances. The ingress listeners only accept traffic from the remote nodes, as UDP traffic between the
+// nodes is never authenticated and TCP traffic only if mutual TLS is enabled.
+func (x *Server) makeServicePortListeners(localNodeID string, cfg *config.Config, servicePorts []*servicePortInstances) ([]types.Resource, error) {
+	listeners := make([]types.Resource, 0)
+	remoteNodeAddresses := x.remoteNodeAddresses(localNodeID)
+
+	for _, s := range servicePorts {
+		logging.DefaultLogger.Debug().
+			Str("Bundle", s.bundleID).
+			Str("Service-Port", s.port.Name).
+			Str("Protocol", string(s.port.Protocol)).
+			Uint32("Port", s.port.Port).
+			Str("Cluster", s.clusterName(false)).
+			Msg("Registering service port listener")
+
+		egressListener, err := makeServicePortListener(
+			fmt.Sprintf("egress_%v_listener", s.key()),
+			s.port.Protocol,
+			s.port.Port,
+			s.clusterName(false),
+			core.TrafficDirection_OUTBOUND,
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		listeners = append(listeners, egressListener)
+
+		if len(s.localPorts) == 0 || len(remoteNodeAddresses) == 0 {
+			continue
+		}
+
+		ingressListener, err := makeServicePortListener(
+			fmt.Sprintf("ingress_%v_listener", s.key()),
+			s.port.Protocol,
+			ingressPort(cfg, s.port),
+			s.clusterName(true),
+			core.TrafficDirection_INBOUND,
+			remoteNodeAddresses,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// remote nodes have to authenticate themselves
+		if x.mTLSEnabled() && s.port.Protocol == config.TransportProtocolTCP {
+			ingressListener.FilterChains[0].TransportSocket = makeDownstreamTransportSocket(nil)
+		}
+
+		listeners = append(listeners, ingressListener)
+	}
+
+	return listeners, nil
+}
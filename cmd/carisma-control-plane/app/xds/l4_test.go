@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	cncfmatcher "github.com/cncf/xds/go/xds/type/matcher/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	udp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func newServicePortTestServer() *Server {
+	ports := []config.ServicePort{
+		{Name: "mqtt", Protocol: config.TransportProtocolTCP, Port: 20001, TargetPort: 1883},
+		{Name: "someip", Protocol: config.TransportProtocolUDP, Port: 20002, TargetPort: 30490},
+	}
+
+	return newTestServer(registry.ServiceConfigSnapshot{
+		testLocalNodeID: {
+			"gateway": {
+				Versions:     map[string][]int32{"v1": {8080}},
+				Ports:        ports,
+				PortVersions: map[string]map[string][]int32{"mqtt": {"v1": {31883}}, "someip": {"v1": {30490}}},
+			},
+		},
+	})
+}
+
+func servicePortListeners(t *testing.T, x *Server, localNodeID string) map[string]*listener.Listener {
+	cfg := config.Default()
+
+	resources, err := x.makeServicePortListeners(localNodeID, cfg, x.servicePorts(localNodeID, cfg))
+	assert.NilError(t, err)
+
+	listeners := make(map[string]*listener.Listener, len(resources))
+	for _, r := range resources {
+		l := r.(*listener.Listener)
+		listeners[l.Name] = l
+	}
+
+	return listeners
+}
+
+func TestServicePortIngressOnlyAcceptsRemoteNodes(t *testing.T) {
+	listeners := servicePortListeners(t, newServicePortTestServer(), testLocalNodeID)
+	assert.Equal(t, len(listeners), 4)
+
+	tcpIngress := listeners["ingress_gateway_mqtt_tcp_listener"]
+	assert.Assert(t, tcpIngress != nil)
+	assert.Equal(t, len(tcpIngress.FilterChains), 1)
+
+	ranges := tcpIngress.FilterChains[0].GetFilterChainMatch().GetSourcePrefixRanges()
+	assert.Equal(t, len(ranges), 2)
+	assert.Equal(t, ranges[0].AddressPrefix, "10.0.0.2")
+	assert.Equal(t, ranges[0].PrefixLen.GetValue(), uint32(32))
+	assert.Equal(t, ranges[1].AddressPrefix, "10.0.0.3")
+
+	udpIngress := listeners["ingress_gateway_someip_udp_listener"]
+	assert.Assert(t, udpIngress != nil)
+
+	udpProxy := &udp.UdpProxyConfig{}
+	assert.NilError(t, udpIngress.ListenerFilters[0].GetTypedConfig().UnmarshalTo(udpProxy))
+
+	// datagrams of other sources match no route and are dropped
+	matcher := udpProxy.GetMatcher()
+	assert.Assert(t, matcher.OnNoMatch == nil)
+	assert.Equal(t, matcher.GetMatcherTree().GetInput().GetName(), sourceIPInputName)
+
+	customMatch := matcher.GetMatcherTree().GetCustomMatch()
+	assert.Equal(t, customMatch.GetName(), ipMatcherName)
+
+	ipMatcher := &cncfmatcher.IPMatcher{}
+	assert.NilError(t, customMatch.GetTypedConfig().UnmarshalTo(ipMatcher))
+	assert.Equal(t, len(ipMatcher.RangeMatchers), 1)
+	assert.Equal(t, len(ipMatcher.RangeMatchers[0].Ranges), 2)
+	assert.Equal(t, ipMatcher.RangeMatchers[0].Ranges[0].AddressPrefix, "10.0.0.2")
+	assert.Equal(t, ipMatcher.RangeMatchers[0].Ranges[1].AddressPrefix, "10.0.0.3")
+	assert.Assert(t, ipMatcher.RangeMatchers[0].OnMatch.GetAction() != nil)
+
+	// the egress listeners are reached by the local bundles only, see config.Config.EgressHostIP
+	assert.Assert(t, listeners["egress_gateway_mqtt_tcp_listener"].FilterChains[0].FilterChainMatch == nil)
+
+	udpProxy = &udp.UdpProxyConfig{}
+	assert.NilError(t, listeners["egress_gateway_someip_udp_listener"].ListenerFilters[0].GetTypedConfig().UnmarshalTo(udpProxy))
+	assert.Assert(t, udpProxy.GetMatcher().OnNoMatch.GetAction() != nil)
+}
+
+func TestServicePortIngressWithoutRemoteNodes(t *testing.T) {
+	x := newServicePortTestServer()
+	delete(x.nodes, testRemoteNodeID1)
+	delete(x.nodes, testRemoteNodeID2)
+
+	listeners := servicePortListeners(t, x, testLocalNodeID)
+	assert.Equal(t, len(listeners), 2)
+	assert.Assert(t, listeners["egress_gateway_mqtt_tcp_listener"] != nil)
+	assert.Assert(t, listeners["egress_gateway_someip_udp_listener"] != nil)
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/metrics"
+)
+
+var (
+	metricNodes = metrics.NewGauge("carisma_control_plane_nodes",
+		"The number of registered nodes.")
+	metricServices = metrics.NewGauge("carisma_control_plane_services",
+		"The number of bundles announced by a node.", "node")
+	metricSnapshotVersion = metrics.NewGauge("carisma_control_plane_snapshot_version",
+		"The version of the most recently generated snapshots.")
+	metricSnapshotGeneration = metrics.NewHistogram("carisma_control_plane_snapshot_generation_seconds",
+		"The time it takes to generate the snapshots of all nodes.", metrics.DefaultBuckets)
+	metricADSStreams = metrics.NewGauge("carisma_control_plane_ads_streams",
+		"The number of open ADS streams.")
+	metricNACKs = metrics.NewCounter("carisma_control_plane_nacks_total",
+		"The number of configuration updates rejected by the Envoy of a node.", "node", "type")
+)
+
+// recordRegistry records the registered nodes and their services.
+func (x *Server) recordRegistry() {
+	metricNodes.Set(float64(len(x.nodes)))
+	metricSnapshotVersion.Set(float64(x.snapshotVersion))
+
+	// nodes that left the registry must not be exported any longer
+	metricServices.Reset()
+	for nodeID := range x.nodes {
+		metricServices.Set(float64(len(x.services[nodeID])), nodeID)
+	}
+}
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"strings"
+	"time"
+)
+
+const (
+	// Connect timeout of clusters without a declared traffic policy.
+	defaultConnectTimeout = 1 * time.Second
+
+	// Defaults of the values a declared health check leaves unset.
+	defaultHealthCheckInterval           = 10 * time.Second
+	defaultHealthCheckTimeout            = 1 * time.Second
+	defaultHealthCheckUnhealthyThreshold = 3
+	defaultHealthCheckHealthyThreshold   = 2
+	defaultHealthCheckPath               = "/healthz"
+)
+
+var lbPolicies = map[config.LBPolicy]cluster.Cluster_LbPolicy{
+	config.LBPolicyRoundRobin:   cluster.Cluster_ROUND_ROBIN,
+	config.LBPolicyLeastRequest: cluster.Cluster_LEAST_REQUEST,
+	config.LBPolicyRandom:       cluster.Cluster_RANDOM,
+}
+
+// declaringService returns the service of the provided bundle on the node with the lowest ID that declares the
+// property checked by the provided function, so that every node receives the same configuration if the nodes disagree.
+func (x *Server) declaringService(bundleID string, declares func(service *registry.ServiceConfig) bool) *registry.ServiceConfig {
+	nodeIDs := maps.Keys(x.services)
+	slices.Sort(nodeIDs)
+
+	for _, nodeID := range nodeIDs {
+		if service, ok := x.services[nodeID][bundleID]; ok && declares(service) {
+			return service
+		}
+	}
+
+	return nil
+}
+
+// bundlePolicy returns the traffic policy declared by the provided bundle. If the nodes disagree, the policy of the
+// node with the lowest ID wins.
+func (x *Server) bundlePolicy(bundleID string) *config.TrafficPolicy {
+	if service := x.declaringService(bundleID, func(s *registry.ServiceConfig) bool { return s.Policy != nil }); service != nil {
+		return service.Policy
+	}
+
+	return nil
+}
+
+func millis(ms uint32) *durationpb.Duration {
+	return durationpb.New(time.Duration(ms) * time.Millisecond)
+}
+
+// applyClusterPolicy sets the connect timeout, load balancing policy and circuit breakers of the provided cluster.
+func applyClusterPolicy(c *cluster.Cluster, policy *config.TrafficPolicy) {
+	c.ConnectTimeout = durationpb.New(defaultConnectTimeout)
+	c.LbPolicy = cluster.Cluster_ROUND_ROBIN
+
+	if policy == nil {
+		return
+	}
+
+	if policy.ConnectTimeoutMs > 0 {
+		c.ConnectTimeout = millis(policy.ConnectTimeoutMs)
+	}
+
+	if lbPolicy, ok := lbPolicies[policy.LBPolicy]; ok {
+		c.LbPolicy = lbPolicy
+	}
+
+	if cb := policy.CircuitBreaker; cb != nil {
+		thresholds := &cluster.CircuitBreakers_Thresholds{
+			Priority: core.RoutingPriority_DEFAULT,
+		}
+
+		if cb.MaxConnections > 0 {
+			thresholds.MaxConnections = wrapperspb.UInt32(cb.MaxConnections)
+		}
+
+		if cb.MaxPendingRequests > 0 {
+			thresholds.MaxPendingRequests = wrapperspb.UInt32(cb.MaxPendingRequests)
+		}
+
+		if cb.MaxRequests > 0 {
+			thresholds.MaxRequests = wrapperspb.UInt32(cb.MaxRequests)
+		}
+
+		if cb.MaxRetries > 0 {
+			thresholds.MaxRetries = wrapperspb.UInt32(cb.MaxRetries)
+		}
+
+		c.CircuitBreakers = &cluster.CircuitBreakers{
+			Thresholds: []*cluster.CircuitBreakers_Thresholds{thresholds},
+		}
+	}
+}
+
+// durationOrDefault converts the provided milliseconds into a duration, unset values fall back to the provided default.
+func durationOrDefault(ms uint32, d time.Duration) *durationpb.Duration {
+	if ms > 0 {
+		return millis(ms)
+	}
+
+	return durationpb.New(d)
+}
+
+func uint32OrDefault(v, d uint32) *wrapperspb.UInt32Value {
+	if v > 0 {
+		return wrapperspb.UInt32(v)
+	}
+
+	return wrapperspb.UInt32(d)
+}
+
+// applyHealthCheck lets Envoy actively probe the instances of the provided cluster if the provided policy declares a
+// health check. gRPC bundles are probed via the grpc.health.v1 health checking protocol, HTTP bundles via GET requests.
+// Instances on remote nodes are excluded from active health checks, see makeLoadAssignment, as they are probed by the
+// Envoy on their own node.
+func applyHealthCheck(c *cluster.Cluster, protocol config.Protocol, policy *config.TrafficPolicy) {
+	if policy == nil || policy.HealthCheck == nil {
+		return
+	}
+
+	hc := policy.HealthCheck
+
+	healthCheck := &core.HealthCheck{
+		Interval:           durationOrDefault(hc.IntervalMs, defaultHealthCheckInterval),
+		Timeout:            durationOrDefault(hc.TimeoutMs, defaultHealthCheckTimeout),
+		UnhealthyThreshold: uint32OrDefault(hc.UnhealthyThreshold, defaultHealthCheckUnhealthyThreshold),
+		HealthyThreshold:   uint32OrDefault(hc.HealthyThreshold, defaultHealthCheckHealthyThreshold),
+	}
+
+	switch protocol {
+	case config.ProtocolHTTP, config.ProtocolHTTP2:
+		path := hc.Path
+		if path == "" {
+			path = defaultHealthCheckPath
+		}
+
+		codecClientType := typev3.CodecClientType_HTTP2
+		if protocol == config.ProtocolHTTP {
+			codecClientType = typev3.CodecClientType_HTTP1
+		}
+
+		healthCheck.HealthChecker = &core.HealthCheck_HttpHealthCheck_{
+			HttpHealthCheck: &core.HealthCheck_HttpHealthCheck{
+				Path:            path,
+				CodecClientType: codecClientType,
+			},
+		}
+	default:
+		healthCheck.HealthChecker = &core.HealthCheck_GrpcHealthCheck_{
+			GrpcHealthCheck: &core.HealthCheck_GrpcHealthCheck{
+				ServiceName: hc.ServiceName,
+			},
+		}
+	}
+
+	c.HealthChecks = []*core.HealthCheck{healthCheck}
+}
+
+// applyOutlierDetection enables the outlier detection of the provided cluster. Instances that repeatedly fail are
+// ejected, which makes Envoy fail over to the instances of the next lower priority once too few instances of a priority
+// are left. Thresholds the provided policy leaves unset fall back to the defaults of Envoy.
+func applyOutlierDetection(c *cluster.Cluster, policy *config.TrafficPolicy) {
+	c.OutlierDetection = &cluster.OutlierDetection{}
+
+	if policy == nil || policy.OutlierDetection == nil {
+		return
+	}
+
+	od := policy.OutlierDetection
+
+	if od.Consecutive5xx > 0 {
+		c.OutlierDetection.Consecutive_5Xx = wrapperspb.UInt32(od.Consecutive5xx)
+	}
+
+	if od.ConsecutiveGatewayFailure > 0 {
+		c.OutlierDetection.ConsecutiveGatewayFailure = wrapperspb.UInt32(od.ConsecutiveGatewayFailure)
+		c.OutlierDetection.EnforcingConsecutiveGatewayFailure = wrapperspb.UInt32(100)
+	}
+
+	if od.IntervalMs > 0 {
+		c.OutlierDetection.Interval = millis(od.IntervalMs)
+	}
+
+	if od.BaseEjectionTimeMs > 0 {
+		c.OutlierDetection.BaseEjectionTime = millis(od.BaseEjectionTimeMs)
+	}
+
+	if od.MaxEjectionPercent > 0 {
+		c.OutlierDetection.MaxEjectionPercent = wrapperspb.UInt32(od.MaxEjectionPercent)
+	}
+}
+
+// makeRouteAction creates a route action without cluster specifier that applies the provided policy. Retries are only
+// performed by the calling side, as retries on both sides would multiply the number of attempts.
+func makeRouteAction(policy *config.TrafficPolicy, withRetries bool) *route.RouteAction {
+	action := &route.RouteAction{}
+
+	if policy == nil {
+		return action
+	}
+
+	if policy.TimeoutMs > 0 {
+		action.Timeout = millis(policy.TimeoutMs)
+	}
+
+	if withRetries && policy.Retry != nil && len(policy.Retry.RetryOn) > 0 {
+		action.RetryPolicy = &route.RetryPolicy{
+			RetryOn:    strings.Join(policy.Retry.RetryOn, ","),
+			NumRetries: wrapperspb.UInt32(policy.Retry.NumRetries),
+		}
+
+		if policy.Retry.PerTryTimeoutMs > 0 {
+			action.RetryPolicy.PerTryTimeout = millis(policy.Retry.PerTryTimeoutMs)
+		}
+	}
+
+	return action
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+// healthCheckSummary encodes the health checks of the provided cluster as "<interval>/<timeout> <unhealthy threshold>/
+// <healthy threshold>" followed by the probe, which is either "grpc <service name>" or "<codec> <path>".
+func healthCheckSummary(c *cluster.Cluster) []string {
+	summary := make([]string, 0, len(c.HealthChecks))
+	for _, hc := range c.HealthChecks {
+		s := fmt.Sprintf("%v/%v %v/%v", hc.Interval.AsDuration(), hc.Timeout.AsDuration(),
+			hc.UnhealthyThreshold.GetValue(), hc.HealthyThreshold.GetValue())
+
+		if grpcHealthCheck := hc.GetGrpcHealthCheck(); grpcHealthCheck != nil {
+			s += fmt.Sprintf(" grpc %q", grpcHealthCheck.ServiceName)
+		} else {
+			s += fmt.Sprintf(" %v %v", hc.GetHttpHealthCheck().CodecClientType, hc.GetHttpHealthCheck().Path)
+		}
+
+		summary = append(summary, s)
+	}
+
+	return summary
+}
+
+func TestApplyHealthCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol config.Protocol
+		policy   *config.TrafficPolicy
+		want     []string
+	}{
+		{
+			name: "no policy",
+			want: []string{},
+		},
+		{
+			name:   "policy without health check",
+			policy: &config.TrafficPolicy{TimeoutMs: 500},
+			want:   []string{},
+		},
+		{
+			name:   "gRPC bundles are probed via the gRPC health checking protocol",
+			policy: &config.TrafficPolicy{HealthCheck: &config.HealthCheck{}},
+			want:   []string{`10s/1s 3/2 grpc ""`},
+		},
+		{
+			name:     "HTTP bundles are probed via HTTP/1.1",
+			protocol: config.ProtocolHTTP,
+			policy:   &config.TrafficPolicy{HealthCheck: &config.HealthCheck{}},
+			want:     []string{"10s/1s 3/2 HTTP1 /healthz"},
+		},
+		{
+			name:     "HTTP/2 bundles are probed via HTTP/2",
+			protocol: config.ProtocolHTTP2,
+			policy:   &config.TrafficPolicy{HealthCheck: &config.HealthCheck{Path: "/ready"}},
+			want:     []string{"10s/1s 3/2 HTTP2 /ready"},
+		},
+		{
+			name:     "declared values replace the defaults",
+			protocol: config.ProtocolGRPC,
+			policy: &config.TrafficPolicy{HealthCheck: &config.HealthCheck{
+				IntervalMs:         2000,
+				TimeoutMs:          250,
+				UnhealthyThreshold: 5,
+				HealthyThreshold:   1,
+				Path:               "/ready",
+				ServiceName:        "brake.v1.Brake",
+			}},
+			want: []string{`2s/250ms 5/1 grpc "brake.v1.Brake"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &cluster.Cluster{Name: "brake_v1_cluster"}
+			applyHealthCheck(c, test.protocol, test.policy)
+
+			assert.DeepEqual(t, healthCheckSummary(c), test.want)
+		})
+	}
+}
+
+func TestApplyOutlierDetection(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *config.TrafficPolicy
+		want   string
+	}{
+		{
+			name: "no policy keeps the defaults of Envoy",
+			want: "5xx=0 gateway=0/0 interval=0s ejection=0s max=0",
+		},
+		{
+			name:   "a policy without outlier detection keeps the defaults of Envoy",
+			policy: &config.TrafficPolicy{TimeoutMs: 500},
+			want:   "5xx=0 gateway=0/0 interval=0s ejection=0s max=0",
+		},
+		{
+			name: "declared thresholds",
+			policy: &config.TrafficPolicy{OutlierDetection: &config.OutlierDetection{
+				Consecutive5xx:            3,
+				ConsecutiveGatewayFailure: 2,
+				IntervalMs:                5000,
+				BaseEjectionTimeMs:        30000,
+				MaxEjectionPercent:        50,
+			}},
+			want: "5xx=3 gateway=2/100 interval=5s ejection=30s max=50",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &cluster.Cluster{Name: "brake_v1_cluster"}
+			applyOutlierDetection(c, test.policy)
+
+			// the outlier detection is always enabled, so that Envoy fails over to the next lower priority
+			od := c.OutlierDetection
+			assert.Assert(t, od != nil)
+			assert.Equal(t, fmt.Sprintf("5xx=%v gateway=%v/%v interval=%v ejection=%v max=%v",
+				od.Consecutive_5Xx.GetValue(), od.ConsecutiveGatewayFailure.GetValue(), od.EnforcingConsecutiveGatewayFailure.GetValue(),
+				od.Interval.AsDuration(), od.BaseEjectionTime.AsDuration(), od.MaxEjectionPercent.GetValue()), test.want)
+		})
+	}
+}
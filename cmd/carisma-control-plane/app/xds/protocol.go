@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/anypb"
+	"strings"
+)
+
+const (
+	// Domain of the virtual host that routes requests regardless of their host.
+	wildcardDomain = "*"
+)
+
+// bundleProtocol returns the protocol declared by the provided bundle, see bundlePolicy.
+func (x *Server) bundleProtocol(bundleID string) config.Protocol {
+	if service := x.declaringService(bundleID, func(s *registry.ServiceConfig) bool { return s.Protocol != "" }); service != nil {
+		return service.Protocol
+	}
+
+	return config.ProtocolGRPC
+}
+
+// bundleRoutes returns the HTTP routes declared by the provided bundle, see bundlePolicy.
+func (x *Server) bundleRoutes(bundleID string) []config.HTTPRoute {
+	if service := x.declaringService(bundleID, func(s *registry.ServiceConfig) bool { return len(s.Routes) > 0 }); service != nil {
+		return service.Routes
+	}
+
+	return nil
+}
+
+// grpcPrefix returns the path prefix of the requests to the provided gRPC bundle.
+func grpcPrefix(bundleID string) string {
+	return fmt.Sprintf("/%v", bundleID)
+}
+
+// prefixesOverlap reports whether a request path can match both provided prefixes.
+func prefixesOverlap(a, b string) bool {
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// httpRoutes returns the HTTP routes of all bundles keyed by bundle ID. Routes whose prefix overlaps the prefix of
+// another bundle are skipped, so that no bundle takes over the requests to another bundle. The prefixes of gRPC bundles
+// are always taken, the routes of all other bundles are taken in the order of their bundle IDs.
+func (x *Server) httpRoutes() map[string][]config.HTTPRoute {
+	bundleIDs := make(map[string]struct{})
+	for _, services := range x.services {
+		for bundleID := range services {
+			bundleIDs[bundleID] = struct{}{}
+		}
+	}
+
+	sortedBundleIDs := maps.Keys(bundleIDs)
+	slices.Sort(sortedBundleIDs)
+
+	type takenPrefix struct {
+		prefix string
+		owner  string
+	}
+
+	takenPrefixes := make([]takenPrefix, 0, len(sortedBundleIDs))
+	for _, bundleID := range sortedBundleIDs {
+		if x.bundleProtocol(bundleID) == config.ProtocolGRPC {
+			takenPrefixes = append(takenPrefixes, takenPrefix{prefix: grpcPrefix(bundleID), owner: bundleID})
+		}
+	}
+
+	// the owner of the first taken prefix the provided prefix overlaps with, if any
+	overlappingOwner := func(bundleID, prefix string) (takenPrefix, bool) {
+		for _, taken := range takenPrefixes {
+			if taken.owner != bundleID && prefixesOverlap(prefix, taken.prefix) {
+				return taken, true
+			}
+		}
+
+		return takenPrefix{}, false
+	}
+
+	routes := make(map[string][]config.HTTPRoute)
+	for _, bundleID := range sortedBundleIDs {
+		if x.bundleProtocol(bundleID) == config.ProtocolGRPC {
+			continue
+		}
+
+		accepted := make([]config.HTTPRoute, 0)
+		for _, r := range x.bundleRoutes(bundleID) {
+			if taken, ok := overlappingOwner(bundleID, r.Prefix); ok {
+				logging.DefaultLogger.Error().
+					Str("Bundle", bundleID).
+					Str("Owner", taken.owner).
+					Str("Prefix", r.Prefix).
+					Str("OwnerPrefix", taken.prefix).
+					Msg("route prefix overlaps the prefix of another bundle")
+
+				continue
+			}
+
+			accepted = append(accepted, r)
+		}
+
+		for _, r := range accepted {
+			takenPrefixes = append(takenPrefixes, takenPrefix{prefix: r.Prefix, owner: bundleID})
+		}
+
+		routes[bundleID] = accepted
+	}
+
+	return routes
+}
+
+// upstreamProtocolOptions returns the protocol options of clusters that forward requests to bundles of the provided
+// protocol.
+func upstreamProtocolOptions(protocol config.Protocol) map[string]*anypb.Any {
+	if protocol == config.ProtocolHTTP {
+		return config.HTTP1ProtocolOptions()
+	}
+
+	return config.HTTP2ProtocolOptions()
+}
+
+// upstreamALPNProtocols returns the protocols a cluster that forwards requests to bundles of the provided protocol
+// negotiates with the ingress listener of a remote node.
+func upstreamALPNProtocols(protocol config.Protocol) []string {
+	if protocol == config.ProtocolHTTP {
+		return []string{"http/1.1"}
+	}
+
+	return []string{"h2"}
+}
+
+func makeHeaderMatcher(name, value string) *route.HeaderMatcher {
+	return &route.HeaderMatcher{
+		Name: name,
+		HeaderMatchSpecifier: &route.HeaderMatcher_StringMatch{
+			StringMatch: &matcher.StringMatcher{
+				MatchPattern: &matcher.StringMatcher_Exact{Exact: value},
+			},
+		},
+	}
+}
+
+// bundleMatches returns the matches of the requests to the provided bundle keyed by the domain of the virtual host they
+// belong to. gRPC services are matched by the prefix of their fully-qualified service name. HTTP services are matched
+// by their bundle ID as host and by the routes they declare.
+func bundleMatches(bundleID string, protocol config.Protocol, routes []config.HTTPRoute) map[string][]*route.RouteMatch {
+	if protocol == config.ProtocolGRPC {
+		return map[string][]*route.RouteMatch{
+			wildcardDomain: {{
+				PathSpecifier: &route.RouteMatch_Prefix{
+					Prefix: grpcPrefix(bundleID),
+				},
+				Grpc: &route.RouteMatch_GrpcRouteMatchOptions{},
+			}},
+		}
+	}
+
+	matches := map[string][]*route.RouteMatch{
+		bundleID: {{
+			PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"},
+		}},
+	}
+
+	for _, r := range routes {
+		m := &route.RouteMatch{
+			PathSpecifier: &route.RouteMatch_Prefix{Prefix: r.Prefix},
+		}
+
+		names := maps.Keys(r.Headers)
+		slices.Sort(names)
+
+		for _, name := range names {
+			m.Headers = append(m.Headers, makeHeaderMatcher(name, r.Headers[name]))
+		}
+
+		matches[wildcardDomain] = append(matches[wildcardDomain], m)
+	}
+
+	return matches
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestOverlappingRoutesAreSkipped(t *testing.T) {
+	x := newTestServer(registry.ServiceConfigSnapshot{
+		testLocalNodeID: {
+			"brake": {Versions: map[string][]int32{"v1": {8080}}},
+			"media": {
+				Versions: map[string][]int32{"v1": {8082}},
+				Protocol: config.ProtocolHTTP,
+				Routes:   []config.HTTPRoute{{Prefix: "/api"}, {Prefix: "/brake/status"}, {Prefix: "/media"}},
+			},
+		},
+		testRemoteNodeID1: {
+			"dashboard": {
+				Versions: map[string][]int32{"v1": {8081}},
+				Protocol: config.ProtocolHTTP,
+				Routes:   []config.HTTPRoute{{Prefix: "/"}, {Prefix: "/api/dashboard"}, {Prefix: "/api/dashboard/v2"}},
+			},
+		},
+	})
+
+	// the prefix of the gRPC bundle is always taken, overlapping routes of HTTP bundles go to the lower bundle ID
+	assert.DeepEqual(t, x.httpRoutes(), map[string][]config.HTTPRoute{
+		"dashboard": {{Prefix: "/api/dashboard"}, {Prefix: "/api/dashboard/v2"}},
+		"media":     {{Prefix: "/media"}},
+	})
+
+	resources, err := x.makeRoutes(testLocalNodeID, config.Default())
+	assert.NilError(t, err)
+
+	var prefixes []string
+	for _, vhost := range resources[0].(*route.RouteConfiguration).VirtualHosts {
+		if vhost.Name != "grpc_vhost" {
+			continue
+		}
+
+		for _, r := range vhost.Routes {
+			prefixes = append(prefixes, r.Name+" "+r.Match.GetPrefix())
+		}
+	}
+
+	assert.DeepEqual(t, prefixes, []string{
+		"dashboard /api/dashboard/v2",
+		"dashboard /api/dashboard",
+		"brake /brake",
+		"media /media",
+	})
+}
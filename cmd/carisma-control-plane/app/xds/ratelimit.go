@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	ratelimitconfig "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ratelimitcommon "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	localratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	ratelimitfilter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/ratelimit"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"math"
+	"time"
+)
+
+const (
+	localRateLimitFilterName  = "envoy.filters.http.local_ratelimit"
+	localRateLimitStatPrefix  = "local_rate_limit"
+	globalRateLimitStatPrefix = "global_rate_limit"
+
+	// The local and the global rate limit filter only consider the rate limits of a route that belong to their stage.
+	localRateLimitStage  = 0
+	globalRateLimitStage = 1
+
+	// Maximum time Envoy waits for the rate limit service before it lets the request pass.
+	globalRateLimitTimeout = 100 * time.Millisecond
+)
+
+// bundleRateLimit returns the rate limit of the provided bundle. The rate limit policies of the mesh take precedence over
+// the rate limit declared by the bundle.
+func (x *Server) bundleRateLimit(bundleID string) *config.RateLimit {
+	if limit, ok := x.limits[bundleID]; ok {
+		return limit
+	}
+
+	if policy := x.bundlePolicy(bundleID); policy != nil {
+		return policy.RateLimit
+	}
+
+	return nil
+}
+
+// BundleRateLimit returns the rate limit of the provided bundle, if any. It is safe for concurrent use, e.g., by the
+// rate limit service.
+func (x *Server) BundleRateLimit(bundleID string) *config.RateLimit {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return x.bundleRateLimit(bundleID)
+}
+
+// makeRateLimitFilters creates the local rate limit filter and, if enabled, the global rate limit filter, which asks the
+// rate limit service of the control plane. Both filters only limit the routes that carry a rate limit, see limitRoute.
+func makeRateLimitFilters(globalRateLimit bool) ([]*hcm.HttpFilter, error) {
+	localConfig, err := anypb.New(&localratelimit.LocalRateLimit{StatPrefix: localRateLimitStatPrefix})
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []*hcm.HttpFilter{{
+		Name:       localRateLimitFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: localConfig},
+	}}
+
+	if !globalRateLimit {
+		return filters, nil
+	}
+
+	globalConfig, err := anypb.New(&ratelimitfilter.RateLimit{
+		Domain:     ratelimit.Domain,
+		Stage:      globalRateLimitStage,
+		Timeout:    durationpb.New(globalRateLimitTimeout),
+		StatPrefix: globalRateLimitStatPrefix,
+		RateLimitService: &ratelimitconfig.RateLimitServiceConfig{
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: config.XDSClusterName},
+				},
+			},
+			TransportApiVersion: core.ApiVersion_V3,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(filters, &hcm.HttpFilter{
+		Name:       wellknown.HTTPRateLimit,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: globalConfig},
+	}), nil
+}
+
+func makeTokenBucket(rps uint32) *typev3.TokenBucket {
+	return &typev3.TokenBucket{
+		MaxTokens:     rps,
+		TokensPerFill: wrapperspb.UInt32(rps),
+		FillInterval:  durationpb.New(time.Second),
+	}
+}
+
+// makeCallerAction creates the action that passes the calling bundle to the rate limit filters. The caller header is
+// set by the identity filter that precedes them, see makeCallerIdentityFilter, and absent for anonymous callers.
+func makeCallerAction() *route.RateLimit_Action {
+	return &route.RateLimit_Action{
+		ActionSpecifier: &route.RateLimit_Action_RequestHeaders_{
+			RequestHeaders: &route.RateLimit_Action_RequestHeaders{
+				HeaderName:    callerHeader,
+				DescriptorKey: ratelimit.DescriptorKeyCaller,
+				SkipIfAbsent:  true,
+			},
+		},
+	}
+}
+
+// makeLocalRateLimit creates the per-route configuration of the local rate limit filter. All requests consume a token
+// of the bucket of the bundle, the requests of callers with a limit of their own additionally a token of their bucket.
+func makeLocalRateLimit(limit *config.RateLimit) (*anypb.Any, []*route.RateLimit, error) {
+	enabled := &core.RuntimeFractionalPercent{
+		DefaultValue: &typev3.FractionalPercent{Numerator: 100, Denominator: typev3.FractionalPercent_HUNDRED},
+	}
+
+	localRateLimit := &localratelimit.LocalRateLimit{
+		StatPrefix:     localRateLimitStatPrefix,
+		TokenBucket:    makeTokenBucket(math.MaxUint32),
+		FilterEnabled:  enabled,
+		FilterEnforced: enabled,
+		Stage:          localRateLimitStage,
+	}
+
+	if limit.RequestsPerSecond > 0 {
+		localRateLimit.TokenBucket = makeTokenBucket(limit.RequestsPerSecond)
+	}
+
+	// keep the order of the descriptors stable, so that an unchanged configuration results in an unchanged resource
+	callers := maps.Keys(limit.PerCaller)
+	slices.Sort(callers)
+
+	for _, caller := range callers {
+		localRateLimit.Descriptors = append(localRateLimit.Descriptors, &ratelimitcommon.LocalRateLimitDescriptor{
+			Entries:     []*ratelimitcommon.RateLimitDescriptor_Entry{{Key: ratelimit.DescriptorKeyCaller, Value: caller}},
+			TokenBucket: makeTokenBucket(limit.PerCaller[caller]),
+		})
+	}
+
+	perRoute, err := anypb.New(localRateLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rateLimits []*route.RateLimit
+	if len(callers) > 0 {
+		rateLimits = []*route.RateLimit{{
+			Stage:   wrapperspb.UInt32(localRateLimitStage),
+			Actions: []*route.RateLimit_Action{makeCallerAction()},
+		}}
+	}
+
+	return perRoute, rateLimits, nil
+}
+
+// makeGlobalRateLimits creates the rate limits of a route that let the global rate limit filter ask the rate limit
+// service for the limit of the bundle and the limit of the caller.
+func makeGlobalRateLimits(bundleID string) []*route.RateLimit {
+	bundleAction := &route.RateLimit_Action{
+		ActionSpecifier: &route.RateLimit_Action_GenericKey_{
+			GenericKey: &route.RateLimit_Action_GenericKey{
+				DescriptorKey:   ratelimit.DescriptorKeyBundle,
+				DescriptorValue: bundleID,
+			},
+		},
+	}
+
+	return []*route.RateLimit{
+		{
+			Stage:   wrapperspb.UInt32(globalRateLimitStage),
+			Actions: []*route.RateLimit_Action{bundleAction},
+		},
+		{
+			Stage:   wrapperspb.UInt32(globalRateLimitStage),
+			Actions: []*route.RateLimit_Action{bundleAction, makeCallerAction()},
+		},
+	}
+}
+
+// limitRoute applies the rate limit of the provided bundle to the provided route, if any. Global rate limits fall back
+// to local rate limits if the rate limit service is disabled. As the route belongs to the egress listener, local rate
+// limits are enforced per calling node.
+func (x *Server) limitRoute(r *route.Route, bundleID string, globalRateLimit bool) (*route.Route, error) {
+	limit := x.bundleRateLimit(bundleID)
+	if limit == nil {
+		return r, nil
+	}
+
+	action := r.GetRoute()
+
+	if limit.Global && globalRateLimit {
+		action.RateLimits = makeGlobalRateLimits(bundleID)
+
+		return r, nil
+	}
+
+	perRoute, rateLimits, err := makeLocalRateLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.TypedPerFilterConfig == nil {
+		r.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	r.TypedPerFilterConfig[localRateLimitFilterName] = perRoute
+	action.RateLimits = rateLimits
+
+	return r, nil
+}
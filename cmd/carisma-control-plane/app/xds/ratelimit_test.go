@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/ratelimit"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestPerCallerRateLimitUsesIdentifiedCaller(t *testing.T) {
+	x := newTestServer(registry.ServiceConfigSnapshot{})
+	x.limits = map[string]*config.RateLimit{
+		"brake": {RequestsPerSecond: 100, PerCaller: map[string]uint32{"dashboard": 10}},
+	}
+
+	r, err := x.limitRoute(&route.Route{Action: &route.Route_Route{Route: &route.RouteAction{}}}, "brake", false)
+	assert.NilError(t, err)
+
+	actions := r.GetRoute().RateLimits[0].Actions
+	assert.Equal(t, len(actions), 1)
+	assert.Equal(t, actions[0].GetRequestHeaders().HeaderName, callerHeader)
+	assert.Equal(t, actions[0].GetRequestHeaders().DescriptorKey, ratelimit.DescriptorKeyCaller)
+
+	// the caller header is set by the identity filter before the rate limit filters evaluate it
+	listeners, err := x.makeHTTPListener(testLocalNodeID, config.Default())
+	assert.NilError(t, err)
+
+	for _, res := range listeners {
+		l := res.(*listener.Listener)
+		if l.Name != egressListenerName {
+			continue
+		}
+
+		manager := &hcm.HttpConnectionManager{}
+		assert.NilError(t, l.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(manager))
+
+		identityIdx, rateLimitIdx := -1, -1
+		for idx, f := range manager.HttpFilters {
+			switch f.Name {
+			case callerIdentityFilterName:
+				identityIdx = idx
+			case localRateLimitFilterName:
+				rateLimitIdx = idx
+			}
+		}
+
+		assert.Assert(t, identityIdx > -1 && identityIdx < rateLimitIdx)
+	}
+}
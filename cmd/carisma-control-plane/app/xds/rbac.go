@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	rbacconfig "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/accesslog"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// Header that carries the bundle ID of the calling bundle. It is set by the egress listener of the calling node based
+	// on the caller token of the bundle, see makeCallerIdentityFilter. Calls between nodes are additionally bound to the
+	// nodes that run the calling bundle via their certificate.
+	callerHeader = accesslog.HeaderCaller
+)
+
+// makeRBACFilter creates an RBAC filter that allows all requests. The requests to bundles that are the target of an
+// authorization policy are checked by the per-route configuration of their routes, see bundleAuthorization.
+func makeRBACFilter() (*hcm.HttpFilter, error) {
+	rbacConfig, err := anypb.New(&rbac.RBAC{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       wellknown.HTTPRoleBasedAccessControl,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rbacConfig},
+	}, nil
+}
+
+func makeAnyPrincipal() *rbacconfig.Principal {
+	return &rbacconfig.Principal{Identifier: &rbacconfig.Principal_Any{Any: true}}
+}
+
+// makeSourcePrincipal creates the principal of the provided source bundle. If the caller is authenticated, i.e., the
+// request was received from a remote node via mutual TLS, the caller must additionally be a node that runs the source
+// bundle.
+func (x *Server) makeSourcePrincipal(source string, authenticated bool) *rbacconfig.Principal {
+	if source == config.AnySource {
+		return makeAnyPrincipal()
+	}
+
+	principal := &rbacconfig.Principal{
+		Identifier: &rbacconfig.Principal_Header{Header: makeHeaderMatcher(callerHeader, source)},
+	}
+
+	if !authenticated {
+		return principal
+	}
+
+	nodeIDs := make([]string, 0)
+	for nodeID, services := range x.services {
+		if _, ok := services[source]; ok {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	slices.Sort(nodeIDs)
+
+	// a source bundle that does not run on any node cannot call from a remote node
+	if len(nodeIDs) == 0 {
+		return &rbacconfig.Principal{Identifier: &rbacconfig.Principal_NotId{NotId: makeAnyPrincipal()}}
+	}
+
+	nodePrincipals := make([]*rbacconfig.Principal, len(nodeIDs))
+	for idx, nodeID := range nodeIDs {
+		nodePrincipals[idx] = &rbacconfig.Principal{
+			Identifier: &rbacconfig.Principal_Authenticated_{
+				Authenticated: &rbacconfig.Principal_Authenticated{
+					PrincipalName: &matcher.StringMatcher{
+						MatchPattern: &matcher.StringMatcher_Exact{Exact: pki.NodeIdentity(nodeID).String()},
+					},
+				},
+			},
+		}
+	}
+
+	return &rbacconfig.Principal{
+		Identifier: &rbacconfig.Principal_AndIds{
+			AndIds: &rbacconfig.Principal_Set{
+				Ids: []*rbacconfig.Principal{
+					principal,
+					{Identifier: &rbacconfig.Principal_OrIds{OrIds: &rbacconfig.Principal_Set{Ids: nodePrincipals}}},
+				},
+			},
+		},
+	}
+}
+
+func makePathPermissions(paths []string) []*rbacconfig.Permission {
+	if len(paths) == 0 {
+		return []*rbacconfig.Permission{{Rule: &rbacconfig.Permission_Any{Any: true}}}
+	}
+
+	permissions := make([]*rbacconfig.Permission, len(paths))
+	for idx, path := range paths {
+		permissions[idx] = &rbacconfig.Permission{
+			Rule: &rbacconfig.Permission_UrlPath{
+				UrlPath: &matcher.PathMatcher{
+					Rule: &matcher.PathMatcher_Path{
+						Path: &matcher.StringMatcher{
+							MatchPattern: &matcher.StringMatcher_Prefix{Prefix: path},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return permissions
+}
+
+// bundleAuthorization compiles the authorization policies that target the provided bundle into the per-route
+// configuration of the RBAC filter, see makeRBACFilter. Bundles that are not the target of any policy may be called by
+// all bundles, thus no configuration is returned.
+func (x *Server) bundleAuthorization(bundleID string, authenticated bool) (*anypb.Any, error) {
+	policies := make(map[string]*rbacconfig.Policy)
+
+	for _, p := range x.policies {
+		if p.Target != bundleID {
+			continue
+		}
+
+		principals := make([]*rbacconfig.Principal, len(p.Sources))
+		for idx, source := range p.Sources {
+			principals[idx] = x.makeSourcePrincipal(source, authenticated)
+		}
+
+		policies[p.Name] = &rbacconfig.Policy{
+			Permissions: makePathPermissions(p.Paths),
+			Principals:  principals,
+		}
+	}
+
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	return anypb.New(&rbac.RBACPerRoute{
+		Rbac: &rbac.RBAC{
+			Rules: &rbacconfig.RBAC{
+				Action:   rbacconfig.RBAC_ALLOW,
+				Policies: policies,
+			},
+		},
+	})
+}
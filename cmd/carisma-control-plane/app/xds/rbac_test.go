@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	rbacconfig "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"strings"
+	"testing"
+)
+
+func newTestAuthorizationServer() *Server {
+	x := newTestServer(registry.ServiceConfigSnapshot{
+		testLocalNodeID: {
+			"brake":     {Versions: map[string][]int32{"v1": {8080}}},
+			"dashboard": {Versions: map[string][]int32{"v1": {8081}}},
+		},
+		testRemoteNodeID1: {
+			"dashboard": {Versions: map[string][]int32{"v1": {8080}}},
+			"radio":     {Versions: map[string][]int32{"v1": {8081}}},
+		},
+	})
+
+	x.policies = []config.AuthorizationPolicy{
+		{Name: "dashboard-to-brake", Target: "brake", Sources: []string{"dashboard", "wiper"}},
+	}
+
+	return x
+}
+
+func unmarshalAuthorization(t *testing.T, r *route.Route) *rbacconfig.Policy {
+	t.Helper()
+
+	perRoute := &rbac.RBACPerRoute{}
+	assert.NilError(t, r.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl].UnmarshalTo(perRoute))
+
+	policy, ok := perRoute.Rbac.Rules.Policies["dashboard-to-brake"]
+	assert.Assert(t, ok)
+
+	return policy
+}
+
+func TestAuthorizationOnlyAttachedToTargets(t *testing.T) {
+	x := newTestAuthorizationServer()
+
+	resources, err := x.makeRoutes(testLocalNodeID, config.Default())
+	assert.NilError(t, err)
+
+	numRoutes := 0
+	for _, res := range resources {
+		for _, vhost := range res.(*route.RouteConfiguration).VirtualHosts {
+			for _, r := range vhost.Routes {
+				_, authorized := r.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl]
+				isTarget := strings.HasPrefix(r.GetRoute().GetCluster(), "brake_") ||
+					strings.HasPrefix(r.GetRoute().GetCluster(), "local_brake_")
+
+				assert.Equal(t, authorized, isTarget, r.GetRoute().GetCluster())
+				numRoutes++
+			}
+		}
+	}
+
+	// the egress routes of all bundles in both traffic classes and the local routes of the local bundles
+	assert.Equal(t, numRoutes, 2*3+2)
+}
+
+func TestAuthenticatedSourcePrincipal(t *testing.T) {
+	x := newTestAuthorizationServer()
+
+	authorization, err := x.bundleAuthorization("brake", true)
+	assert.NilError(t, err)
+
+	policy := unmarshalAuthorization(t, authorizeRoute(&route.Route{}, authorization))
+	assert.Equal(t, len(policy.Principals), 2)
+
+	// the caller header is only trusted if the request was sent by a node that runs the source bundle
+	and := policy.Principals[0].GetAndIds().GetIds()
+	assert.Equal(t, len(and), 2)
+	assert.Equal(t, and[0].GetHeader().GetName(), callerHeader)
+	assert.Equal(t, and[0].GetHeader().GetStringMatch().GetExact(), "dashboard")
+
+	nodes := and[1].GetOrIds().GetIds()
+	assert.Equal(t, len(nodes), 2)
+	assert.Equal(t, nodes[0].GetAuthenticated().GetPrincipalName().GetExact(), pki.NodeIdentity(testLocalNodeID).String())
+	assert.Equal(t, nodes[1].GetAuthenticated().GetPrincipalName().GetExact(), pki.NodeIdentity(testRemoteNodeID1).String())
+
+	// local callers are identified by their caller header only
+	authorization, err = x.bundleAuthorization("brake", false)
+	assert.NilError(t, err)
+
+	policy = unmarshalAuthorization(t, authorizeRoute(&route.Route{}, authorization))
+	assert.Equal(t, policy.Principals[0].GetHeader().GetStringMatch().GetExact(), "dashboard")
+}
+
+func TestAbsentSourceDenied(t *testing.T) {
+	x := newTestAuthorizationServer()
+
+	authorization, err := x.bundleAuthorization("brake", true)
+	assert.NilError(t, err)
+
+	// the wiper bundle does not run on any node
+	policy := unmarshalAuthorization(t, authorizeRoute(&route.Route{}, authorization))
+	assert.Assert(t, policy.Principals[1].GetNotId().GetAny())
+
+	authorization, err = x.bundleAuthorization("radio", true)
+	assert.NilError(t, err)
+	assert.Assert(t, authorization == nil)
+}
@@ -6,65 +6,114 @@ package xds
 import (
 	"context"
 	"fmt"
+	accesslogconfig "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	router "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
 	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
-	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"strings"
+	"time"
 )
 
 const (
 	gRPCRouteName       = "grpc_route"
 	localGRPCRouteName  = "local_grpc_route"
-	gRPCVHostName       = "grpc_vhost"
-	localGRPCVHostName  = "local_grpc_vhost"
 	ingressStatPrefix   = "ingress_http"
 	egressStatPrefix    = "egress_http"
 	ingressListenerName = "ingress_listener"
 	egressListenerName  = "egress_listener"
 )
 
-func generateNodeID(id int) string {
-	return fmt.Sprintf("node-%v", id)
-}
-
-func makeHTTPConnectionManager() ([]*anypb.Any, error) {
+// makeHTTPConnectionManager creates the HTTP connection managers of the ingress and the egress listener of the provided
+// traffic class. The egress listener identifies the calling bundles by the provided identity filter. If a caller filter
+// is provided, the egress listener only admits the callers it allows.
+func makeHTTPConnectionManager(cfg *config.Config, class trafficClass, identityFilter, callerFilter *hcm.HttpFilter) ([]*anypb.Any, error) {
 	routerConfig, _ := anypb.New(&router.Router{})
+
+	rbacFilter, err := makeRBACFilter()
+	if err != nil {
+		return []*anypb.Any{}, err
+	}
+
+	// faults and rate limits are applied by the calling node, see injectFaults and limitRoute
+	faultFilter, err := makeFaultFilter()
+	if err != nil {
+		return []*anypb.Any{}, err
+	}
+
+	rateLimitFilters, err := makeRateLimitFilters(cfg.EnableGlobalRateLimit)
+	if err != nil {
+		return []*anypb.Any{}, err
+	}
+
+	var accessLogs []*accesslogconfig.AccessLog
+	if cfg.EnableAccessLog {
+		if accessLogs, err = makeAccessLogs(); err != nil {
+			return []*anypb.Any{}, err
+		}
+	}
+
+	var tracing *hcm.HttpConnectionManager_Tracing
+	if cfg.EnableTracing {
+		if tracing, err = makeTracing(cfg); err != nil {
+			return []*anypb.Any{}, err
+		}
+	}
+
+	routerFilter := &hcm.HttpFilter{
+		Name:       wellknown.Router,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerConfig},
+	}
+
+	// HTTP bundles may upgrade their connections to WebSocket connections
+	upgradeConfigs := []*hcm.HttpConnectionManager_UpgradeConfig{{UpgradeType: "websocket"}}
+
+	egressFilters := []*hcm.HttpFilter{identityFilter, rbacFilter}
+	if callerFilter != nil {
+		egressFilters = append(egressFilters, callerFilter)
+	}
+	egressFilters = append(append(append(egressFilters, faultFilter), rateLimitFilters...), routerFilter)
+
 	manager := []*hcm.HttpConnectionManager{
 		{
 			CodecType:  hcm.HttpConnectionManager_AUTO,
-			StatPrefix: ingressStatPrefix,
+			StatPrefix: class.ingressStatPrefix,
+			// HTTP bundles are matched by their bundle ID as host, regardless of the port used by the caller
+			StripPortMode:  &hcm.HttpConnectionManager_StripAnyHostPort{StripAnyHostPort: true},
+			UpgradeConfigs: upgradeConfigs,
 			RouteSpecifier: &hcm.HttpConnectionManager_Rds{
 				Rds: &hcm.Rds{
 					ConfigSource:    getConfigSource(),
-					RouteConfigName: localGRPCRouteName,
+					RouteConfigName: class.localRouteName,
 				},
 			},
-			HttpFilters: []*hcm.HttpFilter{{
-				Name:       wellknown.Router,
-				ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerConfig},
-			}},
+			HttpFilters: []*hcm.HttpFilter{rbacFilter, routerFilter},
+			Tracing:     tracing,
 		},
 		{
-			CodecType:  hcm.HttpConnectionManager_AUTO,
-			StatPrefix: egressStatPrefix,
+			CodecType:      hcm.HttpConnectionManager_AUTO,
+			StatPrefix:     class.egressStatPrefix,
+			StripPortMode:  &hcm.HttpConnectionManager_StripAnyHostPort{StripAnyHostPort: true},
+			UpgradeConfigs: upgradeConfigs,
 			RouteSpecifier: &hcm.HttpConnectionManager_Rds{
 				Rds: &hcm.Rds{
 					ConfigSource:    getConfigSource(),
-					RouteConfigName: gRPCRouteName,
+					RouteConfigName: class.routeName,
 				},
 			},
-			HttpFilters: []*hcm.HttpFilter{{
-				Name:       wellknown.Router,
-				ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerConfig},
-			}},
+			HttpFilters: egressFilters,
+			AccessLog:   accessLogs,
+			Tracing:     tracing,
 		},
 	}
 
@@ -81,169 +130,426 @@ func makeHTTPConnectionManager() ([]*anypb.Any, error) {
 	return []*anypb.Any{marshalledIngressConnectionManagerConfig, marshalledEgressConnectionManagerConfig}, err
 }
 
-func (x *Server) makeRoutes(localNodeID string) []types.Resource {
-	routes := make([]*route.Route, 0)
-	localRoutes := make([]*route.Route, 0)
+// headersKey encodes the header matchers of the provided route in a comparable form.
+func headersKey(r *route.Route) string {
+	var sb strings.Builder
+	for _, h := range r.Match.Headers {
+		sb.WriteString(h.Name)
+		sb.WriteRune('=')
+		sb.WriteString(h.GetStringMatch().GetExact())
+		sb.WriteRune(';')
+	}
+
+	return sb.String()
+}
+
+// sortRoutes keeps the order of the routes stable. As Envoy selects the first matching route, longer prefixes precede
+// shorter ones and routes that match specific headers, e.g., a bundle version, precede the route that matches all
+// requests with the same prefix.
+func sortRoutes(routes []*route.Route) {
+	slices.SortStableFunc(routes, func(a, b *route.Route) int {
+		if c := len(b.Match.GetPrefix()) - len(a.Match.GetPrefix()); c != 0 {
+			return c
+		}
 
-	for nodeID, serviceConfig := range x.services {
-		for bundleID := range serviceConfig {
-			clusterID := generateClusterName(bundleID, nodeID == localNodeID)
+		if c := strings.Compare(a.Match.GetPrefix(), b.Match.GetPrefix()); c != 0 {
+			return c
+		}
 
-			r := &route.Route{
-				Match: &route.RouteMatch{
-					PathSpecifier: &route.RouteMatch_Prefix{
-						Prefix: fmt.Sprintf("/%v", bundleID),
-					},
-					Grpc: &route.RouteMatch_GrpcRouteMatchOptions{},
-				},
-				Action: &route.Route_Route{
-					Route: &route.RouteAction{
-						ClusterSpecifier: &route.RouteAction_Cluster{
-							Cluster: clusterID,
-						},
-					},
-				},
+		if c := len(b.Match.Headers) - len(a.Match.Headers); c != 0 {
+			return c
+		}
+
+		return strings.Compare(headersKey(a), headersKey(b))
+	})
+}
+
+// makeRoute creates a route that forwards the requests matched by the provided match to the provided clusters. If a
+// version is given, the route only matches requests to this bundle version.
+func makeRoute(match *route.RouteMatch, version string, clusters []*route.WeightedCluster_ClusterWeight, policy *config.TrafficPolicy, withRetries bool) *route.Route {
+	r := &route.Route{
+		Match: proto.Clone(match).(*route.RouteMatch),
+	}
+
+	if version != "" {
+		r.Match.Headers = append(r.Match.Headers, makeHeaderMatcher(bundleVersionHeader, version))
+	}
+
+	action := makeRouteAction(policy, withRetries)
+	if len(clusters) == 1 {
+		action.ClusterSpecifier = &route.RouteAction_Cluster{
+			Cluster: clusters[0].Name,
+		}
+		r.RequestHeadersToAdd = clusters[0].RequestHeadersToAdd
+	} else {
+		action.ClusterSpecifier = &route.RouteAction_WeightedClusters{
+			WeightedClusters: &route.WeightedCluster{
+				Clusters: clusters,
+			},
+		}
+	}
+
+	r.Action = &route.Route_Route{Route: action}
+
+	return r
+}
+
+// makeVirtualHosts groups the provided routes, which are keyed by domain, into virtual hosts.
+func makeVirtualHosts(routes map[string][]*route.Route, namePrefix string) []*route.VirtualHost {
+	domains := maps.Keys(routes)
+	slices.Sort(domains)
+
+	virtualHosts := make([]*route.VirtualHost, 0, len(domains))
+	for _, domain := range domains {
+		name := fmt.Sprintf("%v%v_vhost", namePrefix, domain)
+		if domain == wildcardDomain {
+			name = fmt.Sprintf("%vgrpc_vhost", namePrefix)
+		}
+
+		// keep the order of the routes stable, so that an unchanged configuration results in an unchanged resource
+		sortRoutes(routes[domain])
+
+		virtualHosts = append(virtualHosts, &route.VirtualHost{
+			Name:    name,
+			Domains: []string{domain},
+			Routes:  routes[domain],
+		})
+	}
+
+	return virtualHosts
+}
+
+// authorizeRoute lets the RBAC filter check the requests matched by the provided route against the provided per-route
+// configuration, if any.
+func authorizeRoute(r *route.Route, authorization *anypb.Any) *route.Route {
+	if authorization == nil {
+		return r
+	}
+
+	if r.TypedPerFilterConfig == nil {
+		r.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	r.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl] = authorization
+
+	return r
+}
+
+func (x *Server) makeRoutes(localNodeID string, cfg *config.Config) ([]types.Resource, error) {
+	// the wildcard virtual host is always present, even if no bundle is running
+	routes := make(map[string]map[string][]*route.Route, 2*len(trafficClasses))
+	for _, class := range trafficClasses {
+		routes[class.routeName] = map[string][]*route.Route{wildcardDomain: {}}
+		routes[class.localRouteName] = map[string][]*route.Route{wildcardDomain: {}}
+	}
+
+	// routes that overlap the prefix of another bundle are skipped, see httpRoutes
+	httpRoutes := x.httpRoutes()
+
+	for bundleID, versions := range x.bundleVersions(localNodeID) {
+		policy := x.bundlePolicy(bundleID)
+		protocol := x.bundleProtocol(bundleID)
+		criticality := x.bundleCriticality(bundleID)
+
+		// the egress clusters prefer the instances of a version that run on the local node by themselves
+		egressClusters := make(map[string]string, len(versions))
+		ingressClusters := make(map[string]string, len(versions))
+		for version, instances := range versions {
+			egressClusters[version] = generateClusterName(bundleID, version, false)
+			if len(instances.localPorts) > 0 {
+				ingressClusters[version] = generateClusterName(bundleID, version, true)
 			}
+		}
+
+		// local callers are only identified by their caller header, remote callers additionally by their node certificate
+		egressAuthorization, err := x.bundleAuthorization(bundleID, false)
+		if err != nil {
+			return nil, err
+		}
+
+		ingressAuthorization, err := x.bundleAuthorization(bundleID, x.mTLSEnabled())
+		if err != nil {
+			return nil, err
+		}
+
+		for domain, matches := range bundleMatches(bundleID, protocol, httpRoutes[bundleID]) {
+			for _, match := range matches {
+				egressRoute, err := x.limitRoute(
+					makeRoute(match, "", x.makeWeightedClusters(bundleID, egressClusters), policy, true),
+					bundleID,
+					cfg.EnableGlobalRateLimit,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				// the access log refers to the called bundle by the name of the route
+				egressRoute.Name = bundleID
+
+				egressRoutes, err := x.injectFaults(bundleID, authorizeRoute(egressRoute, egressAuthorization))
+				if err != nil {
+					return nil, err
+				}
+
+				// the bundles of every traffic class call the bundle via the egress listener of their class
+				for _, class := range trafficClasses {
+					routes[class.routeName][domain] = append(routes[class.routeName][domain], withPriority(egressRoutes, class.priority)...)
+				}
+
+				if len(ingressClusters) == 0 {
+					continue
+				}
+
+				localRoutes := []*route.Route{authorizeRoute(
+					makeRoute(match, "", x.makeWeightedClusters(bundleID, ingressClusters), policy, false),
+					ingressAuthorization,
+				)}
+
+				// honor the version selected by the calling node if several versions run locally
+				if len(ingressClusters) > 1 {
+					for version, clusterID := range ingressClusters {
+						localRoutes = append(localRoutes, authorizeRoute(
+							makeRoute(
+								match,
+								version,
+								[]*route.WeightedCluster_ClusterWeight{{Name: clusterID}},
+								policy,
+								false,
+							),
+							ingressAuthorization,
+						))
+					}
+				}
+
+				// remote nodes reach the bundle via the ingress listener of its traffic class
+				localRouteName := qmTrafficClass.localRouteName
+				if criticality.SafetyRelevant() {
+					localRouteName = safetyTrafficClass.localRouteName
+					localRoutes = prioritizeCallers(localRoutes)
+				}
 
-			e := logging.DefaultLogger.Debug().
-				Str("Node", localNodeID).
-				Str("Bundle", bundleID).
-				Str("Cluster", clusterID).
-				Str("Route", fmt.Sprintf("/%v", bundleID)).
-				Str("Domain", "*")
-
-			if nodeID != localNodeID {
-				e.
-					Str("gRPCRoute", gRPCRouteName).
-					Str("vHost", gRPCVHostName).
-					Msg("Registering route")
-
-				routes = append(routes, r)
-			} else {
-				e.
-					Str("gRPCRoute", fmt.Sprintf("%s,%s", gRPCRouteName, localGRPCRouteName)).
-					Str("vHost", localGRPCVHostName).
-					Msg("Registering local route")
-
-				localRoutes = append(localRoutes, r)
+				routes[localRouteName][domain] = append(routes[localRouteName][domain], localRoutes...)
 			}
 		}
+
+		logging.DefaultLogger.Debug().
+			Str("Node", localNodeID).
+			Str("Bundle", bundleID).
+			Str("Protocol", string(protocol)).
+			Str("Criticality", string(criticality)).
+			Interface("Clusters", egressClusters).
+			Interface("LocalClusters", ingressClusters).
+			Str("gRPCRoute", classOf(criticality).routeName).
+			Str("LocalgRPCRoute", classOf(criticality).localRouteName).
+			Msg("Registering routes")
 	}
 
-	return []types.Resource{
-		&route.RouteConfiguration{
-			Name: gRPCRouteName,
-			VirtualHosts: []*route.VirtualHost{{
-				Name:    gRPCVHostName,
-				Domains: []string{"*"},
-				Routes:  append(routes, localRoutes...),
-			}},
-		},
-		&route.RouteConfiguration{
-			Name: localGRPCRouteName,
-			VirtualHosts: []*route.VirtualHost{{
-				Name:    localGRPCVHostName,
-				Domains: []string{"*"},
-				Routes:  localRoutes,
-			}},
-		},
+	resources := make([]types.Resource, 0, 2*len(trafficClasses))
+	for _, class := range trafficClasses {
+		egressRouteConfig := &route.RouteConfiguration{
+			Name:         class.routeName,
+			VirtualHosts: makeVirtualHosts(routes[class.routeName], vhostPrefix(class, false)),
+		}
+
+		// only the requests of safety-relevant bundles are marked, regardless of the header set by the caller
+		if class.safetyRelevant {
+			egressRouteConfig.RequestHeadersToAdd = []*core.HeaderValueOption{{
+				Header:       &core.HeaderValue{Key: priorityHeader, Value: priorityHeaderValue},
+				AppendAction: core.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			}}
+		} else {
+			egressRouteConfig.RequestHeadersToRemove = []string{priorityHeader}
+		}
+
+		resources = append(resources, egressRouteConfig, &route.RouteConfiguration{
+			Name:                   class.localRouteName,
+			VirtualHosts:           makeVirtualHosts(routes[class.localRouteName], vhostPrefix(class, true)),
+			RequestHeadersToRemove: []string{priorityHeader},
+		})
 	}
+
+	return resources, nil
 }
 
-func (x *Server) makeHTTPListener(cfg *config.Config) ([]types.Resource, error) {
-	httpConnectionManager, err := makeHTTPConnectionManager()
-	if err != nil {
-		return []types.Resource{}, err
+// vhostPrefix returns the name prefix of the virtual hosts of the route configuration of the provided traffic class.
+func vhostPrefix(class trafficClass, isLocal bool) string {
+	prefix := ""
+	if isLocal {
+		prefix = "local_"
 	}
 
-	logging.DefaultLogger.Debug().
-		Str("gRPCRoute", localGRPCRouteName).
-		Uint32("Port", uint32(cfg.IngressPort)).
-		Msg("Registering ingress listener")
-
-	logging.DefaultLogger.Debug().
-		Str("gRPCRoute", gRPCRouteName).
-		Uint32("Port", uint32(cfg.EgressPort)).
-		Msg("Registering egress listener")
-
-	return []types.Resource{
-		&listener.Listener{
-			Name: ingressListenerName,
-			Address: &core.Address{
-				Address: &core.Address_SocketAddress{
-					SocketAddress: &core.SocketAddress{
-						Protocol: core.SocketAddress_TCP,
-						Address:  "0.0.0.0",
-						PortSpecifier: &core.SocketAddress_PortValue{
-							PortValue: uint32(cfg.IngressPort),
-						},
-					},
+	if class.safetyRelevant {
+		prefix += "safety_"
+	}
+
+	return prefix
+}
+
+func (x *Server) makeHTTPListener(localNodeID string, cfg *config.Config) ([]types.Resource, error) {
+	listeners := make([]types.Resource, 0, 2*len(trafficClasses))
+	for _, class := range trafficClasses {
+		callers := x.localCallers(localNodeID)
+
+		// The egress listener of safety-relevant traffic only identifies and admits the safety-relevant local bundles,
+		// the caller tokens of all other bundles are unknown to it.
+		var callerFilter *hcm.HttpFilter
+		if class.safetyRelevant {
+			maps.DeleteFunc(callers, func(_ string, bundleID string) bool {
+				return !x.bundleCriticality(bundleID).SafetyRelevant()
+			})
+
+			var err error
+			if callerFilter, err = x.makeSafetyCallerFilter(); err != nil {
+				return []types.Resource{}, err
+			}
+		}
+
+		identityFilter, err := makeCallerIdentityFilter(callers)
+		if err != nil {
+			return []types.Resource{}, err
+		}
+
+		httpConnectionManager, err := makeHTTPConnectionManager(cfg, class, identityFilter, callerFilter)
+		if err != nil {
+			return []types.Resource{}, err
+		}
+
+		logging.DefaultLogger.Debug().
+			Str("gRPCRoute", class.localRouteName).
+			Uint32("Port", uint32(class.ingressPort(cfg))).
+			Msgf("Registering %s", class.ingressListenerName)
+
+		logging.DefaultLogger.Debug().
+			Str("gRPCRoute", class.routeName).
+			Uint32("Port", uint32(class.egressPort(cfg))).
+			Msgf("Registering %s", class.egressListenerName)
+
+		ingressFilterChain := &listener.FilterChain{
+			Filters: []*listener.Filter{{
+				Name: wellknown.Router,
+				ConfigType: &listener.Filter_TypedConfig{
+					TypedConfig: httpConnectionManager[0],
 				},
-			},
-			TrafficDirection: core.TrafficDirection_INBOUND,
-			FilterChains: []*listener.FilterChain{{
-				Filters: []*listener.Filter{{
-					Name: wellknown.Router,
-					ConfigType: &listener.Filter_TypedConfig{
-						TypedConfig: httpConnectionManager[0],
-					},
-				}},
 			}},
-		},
-		&listener.Listener{
-			Name: egressListenerName,
-			Address: &core.Address{
-				Address: &core.Address_SocketAddress{
-					SocketAddress: &core.SocketAddress{
-						Protocol: core.SocketAddress_TCP,
-						Address:  "0.0.0.0",
-						PortSpecifier: &core.SocketAddress_PortValue{
-							PortValue: uint32(cfg.EgressPort),
+		}
+
+		// Remote nodes have to authenticate themselves. The egress listener is only published on the host loopback
+		// interface, see config.Config.EgressHostIP, so that only the local bundles send requests that leave the node
+		// via the mutual TLS of the clusters to the remote nodes.
+		if x.mTLSEnabled() {
+			ingressFilterChain.TransportSocket = makeDownstreamTransportSocket([]string{"h2", "http/1.1"})
+		}
+
+		listeners = append(listeners,
+			&listener.Listener{
+				Name: class.ingressListenerName,
+				Address: &core.Address{
+					Address: &core.Address_SocketAddress{
+						SocketAddress: &core.SocketAddress{
+							Protocol: core.SocketAddress_TCP,
+							Address:  "0.0.0.0",
+							PortSpecifier: &core.SocketAddress_PortValue{
+								PortValue: uint32(class.ingressPort(cfg)),
+							},
 						},
 					},
 				},
+				TrafficDirection: core.TrafficDirection_INBOUND,
+				FilterChains:     []*listener.FilterChain{ingressFilterChain},
+				// connections of safety-relevant traffic are not subject to the connection limit of the overload manager
+				IgnoreGlobalConnLimit: class.safetyRelevant,
 			},
-			TrafficDirection: core.TrafficDirection_OUTBOUND,
-			FilterChains: []*listener.FilterChain{{
-				Filters: []*listener.Filter{{
-					Name: wellknown.Router,
-					ConfigType: &listener.Filter_TypedConfig{
-						TypedConfig: httpConnectionManager[1],
+			&listener.Listener{
+				Name: class.egressListenerName,
+				Address: &core.Address{
+					Address: &core.Address_SocketAddress{
+						SocketAddress: &core.SocketAddress{
+							Protocol: core.SocketAddress_TCP,
+							Address:  "0.0.0.0",
+							PortSpecifier: &core.SocketAddress_PortValue{
+								PortValue: uint32(class.egressPort(cfg)),
+							},
+						},
 					},
+				},
+				TrafficDirection: core.TrafficDirection_OUTBOUND,
+				FilterChains: []*listener.FilterChain{{
+					Filters: []*listener.Filter{{
+						Name: wellknown.Router,
+						ConfigType: &listener.Filter_TypedConfig{
+							TypedConfig: httpConnectionManager[1],
+						},
+					}},
 				}},
-			}},
-		},
-	}, nil
+				IgnoreGlobalConnLimit: class.safetyRelevant,
+			},
+		)
+	}
+
+	return listeners, nil
 }
 
 func (x *Server) generateSnapshots(ctx context.Context, cfg *config.Config) error {
+	defer metricSnapshotGeneration.ObserveDuration(time.Now())
+
 	x.snapshotVersion++
-	for i := range x.nodes {
-		nodeID := generateNodeID(i)
+	x.recordRegistry()
 
+	for nodeID := range x.nodes {
 		logging.DefaultLogger.Debug().Msgf("Generating snapshot for %s", nodeID)
 
-		httpListener, err := x.makeHTTPListener(cfg)
+		httpListener, err := x.makeHTTPListener(nodeID, cfg)
 		if err != nil {
 			return err
 		}
 
-		clusters, err := x.makeClusters(nodeID, int32(cfg.IngressPort))
+		clusters, loadAssignments := x.makeClusters(nodeID, cfg)
+
+		servicePorts := x.servicePorts(nodeID, cfg)
+
+		servicePortClusters, servicePortLoadAssignments := x.makeServicePortClusters(nodeID, cfg, servicePorts)
+		clusters = append(clusters, servicePortClusters...)
+		loadAssignments = append(loadAssignments, servicePortLoadAssignments...)
+
+		if cfg.EnableTracing {
+			clusters = append(clusters, makeTraceCollectorCluster(cfg))
+		}
+
+		servicePortListeners, err := x.makeServicePortListeners(nodeID, cfg, servicePorts)
 		if err != nil {
 			return err
 		}
-		snapshot, err := cache.NewSnapshot(
-			fmt.Sprintf("%v.0", x.snapshotVersion),
-			map[resource.Type][]types.Resource{
-				resource.ClusterType:  clusters,
-				resource.RouteType:    x.makeRoutes(nodeID),
-				resource.ListenerType: httpListener,
-			},
-		)
+
+		routes, err := x.makeRoutes(nodeID, cfg)
 		if err != nil {
 			return err
 		}
 
+		resources := map[resource.Type][]types.Resource{
+			resource.ClusterType:  clusters,
+			resource.EndpointType: loadAssignments,
+			resource.RouteType:    routes,
+			resource.ListenerType: append(httpListener, servicePortListeners...),
+		}
+
+		if x.mTLSEnabled() {
+			secrets, err := x.makeSecrets(nodeID, time.Duration(cfg.NodeCertTTL)*config.TimeUnit)
+			if err != nil {
+				return err
+			}
+
+			resources[resource.SecretType] = secrets
+		}
+
+		snapshot, changed, err := x.newSnapshot(nodeID, resources)
+		if err != nil {
+			return err
+		}
+
+		// an unchanged snapshot does not need to be distributed again
+		if _, err := x.cache.GetSnapshot(nodeID); err == nil && !changed {
+			continue
+		}
+
 		if err := x.cache.SetSnapshot(ctx, nodeID, snapshot); err != nil {
 			logging.DefaultLogger.Panic().Err(err).Msg("")
 		} else {
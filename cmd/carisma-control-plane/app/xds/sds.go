@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"net/url"
+	"time"
+)
+
+const (
+	nodeCertificateSecretName = "carisma_node_certificate"
+	caSecretName              = "carisma_ca"
+)
+
+// mTLSEnabled checks whether the traffic between the nodes is protected by mutual TLS.
+func (x *Server) mTLSEnabled() bool {
+	return x.ca != nil
+}
+
+// nodeCertificate returns the certificate of the provided node and issues a new one if none is present yet or if the
+// present one needs to be rotated.
+func (x *Server) nodeCertificate(nodeID string, ttl time.Duration) (*pki.Certificate, error) {
+	if c, ok := x.certificates[nodeID]; ok && !c.NeedsRotation(time.Now()) {
+		return c, nil
+	}
+
+	var dnsNames []string
+	if node, ok := x.nodes[nodeID]; ok && node.Hostname != "" {
+		dnsNames = append(dnsNames, node.Hostname)
+	}
+
+	c, err := x.ca.Issue(nodeID, dnsNames, []*url.URL{pki.NodeIdentity(nodeID)}, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.DefaultLogger.Info().
+		Str("Node", nodeID).
+		Time("NotAfter", c.NotAfter).
+		Msg("Issued node certificate")
+
+	x.certificates[nodeID] = c
+
+	return c, nil
+}
+
+// certificatesNeedRotation checks whether the certificate of any node needs to be rotated.
+func (x *Server) certificatesNeedRotation() bool {
+	now := time.Now()
+	for _, c := range x.certificates {
+		if c.NeedsRotation(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (x *Server) makeSecrets(nodeID string, ttl time.Duration) ([]types.Resource, error) {
+	c, err := x.nodeCertificate(nodeID, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return []types.Resource{
+		&tls.Secret{
+			Name: nodeCertificateSecretName,
+			Type: &tls.Secret_TlsCertificate{
+				TlsCertificate: &tls.TlsCertificate{
+					CertificateChain: &core.DataSource{
+						Specifier: &core.DataSource_InlineBytes{InlineBytes: c.CertificatePEM},
+					},
+					PrivateKey: &core.DataSource{
+						Specifier: &core.DataSource_InlineBytes{InlineBytes: c.PrivateKeyPEM},
+					},
+				},
+			},
+		},
+		&tls.Secret{
+			Name: caSecretName,
+			Type: &tls.Secret_ValidationContext{
+				ValidationContext: &tls.CertificateValidationContext{
+					TrustedCa: &core.DataSource{
+						Specifier: &core.DataSource_InlineBytes{InlineBytes: x.ca.CertificatePEM()},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// makeCommonTLSContext creates a TLS context that presents the certificate of the node and only accepts peers that
+// present a node certificate issued by the certificate authority of the control plane.
+func makeCommonTLSContext(alpnProtocols []string) *tls.CommonTlsContext {
+	return &tls.CommonTlsContext{
+		TlsCertificateSdsSecretConfigs: []*tls.SdsSecretConfig{{
+			Name:      nodeCertificateSecretName,
+			SdsConfig: getConfigSource(),
+		}},
+		ValidationContextType: &tls.CommonTlsContext_CombinedValidationContext{
+			CombinedValidationContext: &tls.CommonTlsContext_CombinedCertificateValidationContext{
+				DefaultValidationContext: &tls.CertificateValidationContext{
+					MatchTypedSubjectAltNames: []*tls.SubjectAltNameMatcher{{
+						SanType: tls.SubjectAltNameMatcher_URI,
+						Matcher: &matcher.StringMatcher{
+							MatchPattern: &matcher.StringMatcher_Prefix{Prefix: pki.NodeIdentityPrefix()},
+						},
+					}},
+				},
+				ValidationContextSdsSecretConfig: &tls.SdsSecretConfig{
+					Name:      caSecretName,
+					SdsConfig: getConfigSource(),
+				},
+			},
+		},
+		AlpnProtocols: alpnProtocols,
+	}
+}
+
+func makeTransportSocket(tlsContext proto.Message) *core.TransportSocket {
+	a, err := anypb.New(tlsContext)
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct TLS transport socket: %s", err))
+	}
+
+	return &core.TransportSocket{
+		Name:       wellknown.TransportSocketTLS,
+		ConfigType: &core.TransportSocket_TypedConfig{TypedConfig: a},
+	}
+}
+
+// makeDownstreamTransportSocket creates the transport socket of listeners that require mutual TLS.
+func makeDownstreamTransportSocket(alpnProtocols []string) *core.TransportSocket {
+	return makeTransportSocket(&tls.DownstreamTlsContext{
+		CommonTlsContext:         makeCommonTLSContext(alpnProtocols),
+		RequireClientCertificate: wrapperspb.Bool(true),
+	})
+}
+
+// makeUpstreamTransportSocket creates the transport socket of clusters that connect to remote nodes via mutual TLS.
+func makeUpstreamTransportSocket(alpnProtocols []string) *core.TransportSocket {
+	return makeTransportSocket(&tls.UpstreamTlsContext{
+		CommonTlsContext: makeCommonTLSContext(alpnProtocols),
+	})
+}
+
+// makeRemoteTransportSocketMatches creates the transport socket matches of clusters with instances on several nodes.
+// Only the endpoints on remote nodes, see makeRemoteEndpointMetadata, are connected to via mutual TLS, while local
+// instances are still connected to in plain text.
+func makeRemoteTransportSocketMatches(alpnProtocols []string) []*cluster.Cluster_TransportSocketMatch {
+	return []*cluster.Cluster_TransportSocketMatch{{
+		Name: "remote",
+		Match: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				remoteEndpointMatchField: structpb.NewBoolValue(true),
+			},
+		},
+		TransportSocket: makeUpstreamTransportSocket(alpnProtocols),
+	}}
+}
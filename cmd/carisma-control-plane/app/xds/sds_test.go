@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	"gotest.tools/v3/assert"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newTestMTLSServer creates a test server, see newTestServer, that protects the traffic between the nodes by mutual TLS.
+func newTestMTLSServer(t *testing.T) *Server {
+	ca, err := pki.NewCA()
+	assert.NilError(t, err)
+
+	x := newTestServer(newTestServices())
+	x.ca = ca
+
+	return x
+}
+
+// parseCertificate parses the first certificate of the provided PEM-encoded data.
+func parseCertificate(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	assert.Assert(t, block != nil)
+
+	c, err := x509.ParseCertificate(block.Bytes)
+	assert.NilError(t, err)
+
+	return c
+}
+
+func TestMakeSecrets(t *testing.T) {
+	x := newTestMTLSServer(t)
+
+	secrets, err := x.makeSecrets(testRemoteNodeID1, time.Hour)
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets), 2)
+
+	nodeCertificate := secrets[0].(*tls.Secret)
+	assert.Equal(t, nodeCertificate.Name, nodeCertificateSecretName)
+	assert.Equal(t, string(nodeCertificate.GetTlsCertificate().PrivateKey.GetInlineBytes()),
+		string(x.certificates[testRemoteNodeID1].PrivateKeyPEM))
+
+	// the certificate identifies the node and is issued by the certificate authority of the control plane
+	c := parseCertificate(t, nodeCertificate.GetTlsCertificate().CertificateChain.GetInlineBytes())
+	assert.Equal(t, len(c.URIs), 1)
+	assert.Equal(t, c.URIs[0].String(), pki.NodeIdentity(testRemoteNodeID1).String())
+	assert.DeepEqual(t, c.DNSNames, []string{"hpc-2"})
+
+	_, err = c.Verify(x509.VerifyOptions{Roots: x.ca.CertPool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NilError(t, err)
+
+	ca := secrets[1].(*tls.Secret)
+	assert.Equal(t, ca.Name, caSecretName)
+	assert.Equal(t, string(ca.GetValidationContext().TrustedCa.GetInlineBytes()), string(x.ca.CertificatePEM()))
+}
+
+func TestNodeCertificate(t *testing.T) {
+	tests := []struct {
+		name      string
+		present   func(t *testing.T, x *Server) *pki.Certificate
+		wantReuse bool
+	}{
+		{
+			name:    "a certificate is issued to nodes without certificate",
+			present: func(t *testing.T, x *Server) *pki.Certificate { return nil },
+		},
+		{
+			name: "a valid certificate is reused",
+			present: func(t *testing.T, x *Server) *pki.Certificate {
+				c, err := x.ca.Issue(testRemoteNodeID1, nil, nil, time.Hour)
+				assert.NilError(t, err)
+
+				return c
+			},
+			wantReuse: true,
+		},
+		{
+			name: "a certificate that needs to be rotated is replaced",
+			present: func(t *testing.T, x *Server) *pki.Certificate {
+				c, err := x.ca.Issue(testRemoteNodeID1, nil, nil, time.Hour)
+				assert.NilError(t, err)
+
+				c.NotBefore = c.NotBefore.Add(-time.Hour)
+				c.NotAfter = c.NotAfter.Add(-time.Hour)
+
+				return c
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := newTestMTLSServer(t)
+
+			present := test.present(t, x)
+			if present != nil {
+				x.certificates[testRemoteNodeID1] = present
+			}
+
+			c, err := x.nodeCertificate(testRemoteNodeID1, time.Hour)
+			assert.NilError(t, err)
+			assert.Equal(t, c == present, test.wantReuse)
+			assert.Equal(t, x.certificates[testRemoteNodeID1], c)
+			assert.Assert(t, !c.NeedsRotation(time.Now()))
+			assert.Assert(t, !x.certificatesNeedRotation())
+		})
+	}
+}
+
+func TestGenerateSnapshotsWithMutualTLS(t *testing.T) {
+	tests := []struct {
+		name                  string
+		mTLS                  bool
+		wantSecrets           []string
+		wantRemoteTLSClusters []string
+	}{
+		{
+			name:                  "plain text without certificate authority",
+			wantSecrets:           []string{},
+			wantRemoteTLSClusters: []string{},
+		},
+		{
+			name:                  "mutual TLS between the nodes",
+			mTLS:                  true,
+			wantSecrets:           []string{caSecretName, nodeCertificateSecretName},
+			wantRemoteTLSClusters: []string{"brake_v1_cluster"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the cluster of the trace collector is resolved via DNS
+			cfg := config.Default()
+			cfg.EnableTracing = false
+
+			x := newTestServer(newTestServices())
+			if test.mTLS {
+				x = newTestMTLSServer(t)
+			}
+
+			assert.NilError(t, x.generateSnapshots(context.Background(), cfg))
+
+			snapshot, err := x.cache.GetSnapshot(testLocalNodeID)
+			assert.NilError(t, err)
+
+			secrets := make([]string, 0)
+			for name := range snapshot.GetResources(resource.SecretType) {
+				secrets = append(secrets, name)
+			}
+
+			sort.Strings(secrets)
+			assert.DeepEqual(t, secrets, test.wantSecrets)
+
+			// only the clusters that contain remote nodes connect to them via mutual TLS
+			remoteTLSClusters := make([]string, 0)
+			for name, r := range snapshot.GetResources(resource.ClusterType) {
+				matches := r.(*cluster.Cluster).TransportSocketMatches
+				if len(matches) == 0 {
+					continue
+				}
+
+				assert.Equal(t, len(matches), 1)
+				assert.Equal(t, matches[0].Name, "remote")
+				assert.Assert(t, matches[0].Match.Fields[remoteEndpointMatchField].GetBoolValue())
+
+				remoteTLSClusters = append(remoteTLSClusters, name)
+			}
+
+			assert.DeepEqual(t, remoteTLSClusters, test.wantRemoteTLSClusters)
+
+			// only the ingress listeners, which accept connections from remote nodes, require a client certificate
+			for name, r := range snapshot.GetResources(resource.ListenerType) {
+				l := r.(*listener.Listener)
+
+				transportSocket := l.FilterChains[0].TransportSocket
+				if !test.mTLS || l.TrafficDirection != core.TrafficDirection_INBOUND {
+					assert.Assert(t, transportSocket == nil, name)
+
+					continue
+				}
+
+				downstream := &tls.DownstreamTlsContext{}
+				assert.NilError(t, transportSocket.GetTypedConfig().UnmarshalTo(downstream))
+				assert.Assert(t, downstream.RequireClientCertificate.GetValue(), name)
+				assert.Equal(t, downstream.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name, nodeCertificateSecretName)
+
+				validation := downstream.CommonTlsContext.GetCombinedValidationContext()
+				assert.Equal(t, validation.ValidationContextSdsSecretConfig.Name, caSecretName)
+				assert.Equal(t, validation.DefaultValidationContext.MatchTypedSubjectAltNames[0].Matcher.GetPrefix(),
+					pki.NodeIdentityPrefix())
+			}
+		})
+	}
+}
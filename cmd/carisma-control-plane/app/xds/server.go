@@ -7,41 +7,83 @@ import (
 	"context"
 	clusterservice "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	discoveryservice "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
 	listenerservice "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
 	routeservice "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
+	secretservice "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
 	"google.golang.org/grpc"
 	"net"
 	"sync"
+	"time"
+)
+
+const (
+	// Interval between two checks whether the certificates of the nodes need to be rotated.
+	certificateRotationCheckInterval = 1 * time.Minute
 )
 
 // Server manages the currently registered nodes and services.
 type Server struct {
-	snapshotVersion int
-	cache           cache.SnapshotCache
+	snapshotVersion  int
+	cache            cache.SnapshotCache
+	resourceVersions map[string]map[resource.Type]resourceVersion
+	callbacks        *callbacks
 
-	channelNodes    chan net.Addr
+	channelNodes    chan registry.NodeSnapshot
 	channelServices chan registry.ServiceConfigSnapshot
+	channelLiveness chan registry.HealthSnapshot
+	channelWeights  chan registry.WeightSnapshot
+	channelPolicies chan []config.AuthorizationPolicy
+	channelLimits   chan []config.RateLimitPolicy
+	channelFaults   chan registry.FaultSnapshot
+
+	mu           sync.RWMutex // protects nodes, addresses, services, weights, policies, limits, faults, nodeHealth and certificates
+	nodes        registry.NodeSnapshot
+	addresses    map[string]string // resolved IPv4 address per node
+	services     registry.ServiceConfigSnapshot
+	weights      registry.WeightSnapshot
+	policies     []config.AuthorizationPolicy
+	limits       map[string]*config.RateLimit
+	faults       registry.FaultSnapshot
+	nodeHealth   registry.HealthSnapshot
+	certificates map[string]*pki.Certificate
 
-	mu       sync.RWMutex // protects nodes and services
-	nodes    []net.Addr
-	services registry.ServiceConfigSnapshot
+	ca *pki.CA
 }
 
-// NewServer creates a new xDS server.
-func NewServer() *Server {
-	c := cache.NewSnapshotCache(false, cache.IDHash{}, nil)
+// NewServer creates a new xDS server. The snapshot cache serves both state-of-the-world and incremental (delta) ADS streams.
+// If a certificate authority is provided, the traffic between the nodes is protected by mutual TLS.
+func NewServer(ca *pki.CA) *Server {
+	c := cache.NewSnapshotCache(true, cache.IDHash{}, nil)
 
 	s := &Server{
-		snapshotVersion: -1,
-		cache:           c,
-		channelNodes:    make(chan net.Addr),
-		channelServices: make(chan registry.ServiceConfigSnapshot),
-		nodes:           make([]net.Addr, 0),
-		services:        make(registry.ServiceConfigSnapshot),
+		snapshotVersion:  -1,
+		cache:            c,
+		resourceVersions: make(map[string]map[resource.Type]resourceVersion),
+		callbacks:        newCallbacks(),
+		channelNodes:     make(chan registry.NodeSnapshot),
+		channelServices:  make(chan registry.ServiceConfigSnapshot),
+		channelLiveness:  make(chan registry.HealthSnapshot),
+		channelWeights:   make(chan registry.WeightSnapshot),
+		channelPolicies:  make(chan []config.AuthorizationPolicy),
+		channelLimits:    make(chan []config.RateLimitPolicy),
+		channelFaults:    make(chan registry.FaultSnapshot),
+		nodes:            make(registry.NodeSnapshot),
+		addresses:        make(map[string]string),
+		services:         make(registry.ServiceConfigSnapshot),
+		weights:          make(registry.WeightSnapshot),
+		limits:           make(map[string]*config.RateLimit),
+		faults:           make(registry.FaultSnapshot),
+		nodeHealth:       make(registry.HealthSnapshot),
+		certificates:     make(map[string]*pki.Certificate),
+		ca:               ca,
 	}
 
 	return s
@@ -49,27 +91,55 @@ func NewServer() *Server {
 
 // RegisterServer attaches an xDS server to the provided gRPC server.
 func (x *Server) RegisterServer(ctx context.Context, grpcSrv *grpc.Server, cfg *config.Config) {
+	// All channels are processed by the same goroutine, so that updates are applied in the order they were issued.
 	go func() {
+		rotationTicker := time.NewTicker(certificateRotationCheckInterval)
+		defer rotationTicker.Stop()
+
 		for {
 			select {
-			case newNode := <-x.channelNodes:
+			case <-ctx.Done():
+				return
+			case <-rotationTicker.C:
 				x.mu.Lock()
 
-				x.nodes = append(x.nodes, newNode)
-				err := x.generateSnapshots(ctx, cfg)
+				// snapshots with renewed certificates are distributed via SDS
+				var err error
+				if x.certificatesNeedRotation() {
+					err = x.generateSnapshots(ctx, cfg)
+				}
 
 				x.mu.Unlock()
 
-				if err != nil {
-					return
+				logging.LogErr(err)
+			case newNodeConfig := <-x.channelNodes:
+				// the nodes are resolved without holding the lock, so that a slow resolver does not stall the registries
+				addresses := x.resolveNodes(ctx, newNodeConfig)
+
+				x.mu.Lock()
+
+				// drop the snapshots of nodes that left the registry
+				for nodeID := range x.nodes {
+					if _, ok := newNodeConfig[nodeID]; !ok {
+						x.cache.ClearSnapshot(nodeID)
+						delete(x.resourceVersions, nodeID)
+						delete(x.nodeHealth, nodeID)
+						delete(x.certificates, nodeID)
+						x.callbacks.forgetNode(nodeID)
+
+						logging.DefaultLogger.Debug().
+							Str("Node", nodeID).
+							Msg("Clearing node-specific snapshot")
+					}
 				}
-			}
-		}
-	}()
 
-	go func() {
-		for {
-			select {
+				x.nodes = newNodeConfig
+				x.addresses = addresses
+				err := x.generateSnapshots(ctx, cfg)
+
+				x.mu.Unlock()
+
+				logging.LogErr(err)
 			case newServiceConfig := <-x.channelServices:
 				x.mu.Lock()
 
@@ -79,20 +149,101 @@ func (x *Server) RegisterServer(ctx context.Context, grpcSrv *grpc.Server, cfg *
 
 				x.mu.Unlock()
 
-				if err != nil {
-					return
+				logging.LogErr(err)
+			case newWeights := <-x.channelWeights:
+				x.mu.Lock()
+
+				x.weights = newWeights
+
+				err := x.generateSnapshots(ctx, cfg)
+
+				x.mu.Unlock()
+
+				logging.LogErr(err)
+			case newPolicies := <-x.channelPolicies:
+				x.mu.Lock()
+
+				x.policies = newPolicies
+
+				err := x.generateSnapshots(ctx, cfg)
+
+				x.mu.Unlock()
+
+				logging.LogErr(err)
+			case newLimits := <-x.channelLimits:
+				x.mu.Lock()
+
+				x.limits = make(map[string]*config.RateLimit, len(newLimits))
+				for _, l := range newLimits {
+					x.limits[l.Target] = &l.RateLimit
 				}
+
+				err := x.generateSnapshots(ctx, cfg)
+
+				x.mu.Unlock()
+
+				logging.LogErr(err)
+			case newFaults := <-x.channelFaults:
+				x.mu.Lock()
+
+				x.faults = newFaults
+
+				err := x.generateSnapshots(ctx, cfg)
+
+				x.mu.Unlock()
+
+				logging.LogErr(err)
+			case newHealth := <-x.channelLiveness:
+				x.mu.Lock()
+
+				// only dead nodes affect the endpoints, i.e., their endpoints are drained
+				drainedChanged := deadNodesChanged(x.nodeHealth, newHealth)
+
+				x.nodeHealth = newHealth
+
+				var err error
+				if drainedChanged {
+					err = x.generateSnapshots(ctx, cfg)
+				}
+
+				x.mu.Unlock()
+
+				logging.LogErr(err)
 			}
 		}
 	}()
 
+	// only registered nodes receive their snapshot
+	x.callbacks.validate = func(nodeID string, addr net.Addr) error {
+		return x.validateNode(ctx, cfg, nodeID, addr)
+	}
+
 	// run the xDS server
-	srv := server.NewServer(ctx, x.cache, nil)
+	srv := server.NewServer(ctx, x.cache, x.callbacks)
 
 	discoveryservice.RegisterAggregatedDiscoveryServiceServer(grpcSrv, srv)
 	clusterservice.RegisterClusterDiscoveryServiceServer(grpcSrv, srv)
+	endpointservice.RegisterEndpointDiscoveryServiceServer(grpcSrv, srv)
 	routeservice.RegisterRouteDiscoveryServiceServer(grpcSrv, srv)
 	listenerservice.RegisterListenerDiscoveryServiceServer(grpcSrv, srv)
+	secretservice.RegisterSecretDiscoveryServiceServer(grpcSrv, srv)
+}
+
+// Restore rebuilds the snapshots of all provided nodes based on the provided services and traffic weights, e.g., after a
+// restart of the control plane.
+func (x *Server) Restore(ctx context.Context, cfg *config.Config, nodes registry.NodeSnapshot, services registry.ServiceConfigSnapshot,
+	weights registry.WeightSnapshot) error {
+	addresses := x.resolveNodes(ctx, nodes)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.nodes = nodes
+	x.addresses = addresses
+	x.services = services
+	x.weights = weights
+
+	return x.generateSnapshots(ctx, cfg)
 }
 
 // RWMutex returns a pointer to the mutex used for protecting shared resources.
@@ -101,11 +252,53 @@ func (x *Server) RWMutex() *sync.RWMutex {
 }
 
 // ChannelNodes returns the channel that can be used to introduce new nodes.
-func (x *Server) ChannelNodes() chan<- net.Addr {
+func (x *Server) ChannelNodes() chan<- registry.NodeSnapshot {
 	return x.channelNodes
 }
 
+// ChannelLiveness returns the channel that can be used to introduce changes regarding the liveness of nodes.
+func (x *Server) ChannelLiveness() chan<- registry.HealthSnapshot {
+	return x.channelLiveness
+}
+
 // ChannelServices returns the channel that can be used to introduce new services.
 func (x *Server) ChannelServices() chan<- registry.ServiceConfigSnapshot {
 	return x.channelServices
 }
+
+// ChannelWeights returns the channel that can be used to introduce new traffic weights.
+func (x *Server) ChannelWeights() chan<- registry.WeightSnapshot {
+	return x.channelWeights
+}
+
+// ChannelPolicies returns the channel that can be used to introduce new authorization policies.
+func (x *Server) ChannelPolicies() chan<- []config.AuthorizationPolicy {
+	return x.channelPolicies
+}
+
+// ChannelRateLimits returns the channel that can be used to introduce new rate limit policies.
+func (x *Server) ChannelRateLimits() chan<- []config.RateLimitPolicy {
+	return x.channelLimits
+}
+
+// ChannelFaults returns the channel that can be used to introduce new faults.
+func (x *Server) ChannelFaults() chan<- registry.FaultSnapshot {
+	return x.channelFaults
+}
+
+// deadNodesChanged reports whether a node died or recovered between the provided health snapshots.
+func deadNodesChanged(prev, next registry.HealthSnapshot) bool {
+	for nodeID, health := range next {
+		if (health == registry.NodeHealthDead) != (prev[nodeID] == registry.NodeHealthDead) {
+			return true
+		}
+	}
+
+	for nodeID, health := range prev {
+		if _, ok := next[nodeID]; !ok && health == registry.NodeHealthDead {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"context"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/v3/assert"
+	"net"
+	"sort"
+	"testing"
+	"time"
+)
+
+const (
+	testLocalNodeID   = "node-hpc-1"
+	testRemoteNodeID1 = "node-hpc-2"
+	testRemoteNodeID2 = "node-hpc-3"
+)
+
+// newTestServer creates an xDS server without certificate authority that knows one local and two remote nodes, which
+// run the provided services.
+func newTestServer(services registry.ServiceConfigSnapshot) *Server {
+	x := NewServer(nil)
+
+	x.nodes = registry.NodeSnapshot{
+		testLocalNodeID:   {Hostname: "hpc-1", Addr: &registry.NodeAddr{Host: "10.0.0.1", Port: 8000}},
+		testRemoteNodeID1: {Hostname: "hpc-2", Addr: &registry.NodeAddr{Host: "10.0.0.2", Port: 8000}},
+		testRemoteNodeID2: {Hostname: "hpc-3", Addr: &registry.NodeAddr{Host: "10.0.0.3", Port: 8000}},
+	}
+	x.addresses = map[string]string{testLocalNodeID: "10.0.0.1", testRemoteNodeID1: "10.0.0.2", testRemoteNodeID2: "10.0.0.3"}
+	x.services = services
+
+	return x
+}
+
+// openTestDeltaStream serves the provided xDS server via an in-memory gRPC connection and opens a delta ADS stream.
+func openTestDeltaStream(t *testing.T, ctx context.Context, x *Server, cfg *config.Config) discovery.AggregatedDiscoveryService_DeltaAggregatedResourcesClient {
+	lis := bufconn.Listen(1024 * 1024)
+	grpcSrv := grpc.NewServer()
+	x.RegisterServer(ctx, grpcSrv, cfg)
+
+	go func() {
+		_ = grpcSrv.Serve(lis)
+	}()
+	t.Cleanup(grpcSrv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///xds",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NilError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	stream, err := discovery.NewAggregatedDiscoveryServiceClient(conn).DeltaAggregatedResources(ctx)
+	assert.NilError(t, err)
+
+	return stream
+}
+
+// deltaSummary returns the sorted names of the resources updated and removed by the provided delta response.
+func deltaSummary(response *discovery.DeltaDiscoveryResponse) ([]string, []string) {
+	updated := make([]string, 0, len(response.Resources))
+	for _, r := range response.Resources {
+		updated = append(updated, r.Name)
+	}
+
+	removed := append(make([]string, 0, len(response.RemovedResources)), response.RemovedResources...)
+
+	sort.Strings(updated)
+	sort.Strings(removed)
+
+	return updated, removed
+}
+
+func TestDeltaADS(t *testing.T) {
+	tests := []struct {
+		name        string
+		services    func() registry.ServiceConfigSnapshot
+		wantUpdated []string
+		wantRemoved []string
+	}{
+		{
+			name: "a new bundle only sends its cluster",
+			services: func() registry.ServiceConfigSnapshot {
+				services := newTestServices()
+				services[testRemoteNodeID2] = map[string]*registry.ServiceConfig{"media": {Versions: map[string][]int32{"v1": {8080}}}}
+
+				return services
+			},
+			wantUpdated: []string{"media_v1_cluster"},
+			wantRemoved: []string{},
+		},
+		{
+			name: "a stopped local instance only removes its local cluster",
+			services: func() registry.ServiceConfigSnapshot {
+				services := newTestServices()
+				delete(services, testLocalNodeID)
+
+				return services
+			},
+			wantUpdated: []string{},
+			wantRemoved: []string{"local_brake_v1_cluster"},
+		},
+		{
+			name: "a changed traffic policy sends the clusters of the bundle",
+			services: func() registry.ServiceConfigSnapshot {
+				services := newTestServices()
+				for _, nodeServices := range services {
+					nodeServices["brake"].Policy = &config.TrafficPolicy{ConnectTimeoutMs: 500}
+				}
+
+				return services
+			},
+			wantUpdated: []string{"brake_v1_cluster", "local_brake_v1_cluster"},
+			wantRemoved: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// the cluster of the trace collector is resolved via DNS, the in-memory connection has no peer address
+			cfg := config.Default()
+			cfg.EnableTracing = false
+			cfg.EnableXDSPeerValidation = false
+
+			x := newTestServer(newTestServices())
+			assert.NilError(t, x.generateSnapshots(ctx, cfg))
+
+			stream := openTestDeltaStream(t, ctx, x, cfg)
+
+			// the initial wildcard request receives all clusters
+			assert.NilError(t, stream.Send(&discovery.DeltaDiscoveryRequest{
+				Node:    &core.Node{Id: testLocalNodeID},
+				TypeUrl: resource.ClusterType,
+			}))
+
+			response, err := stream.Recv()
+			assert.NilError(t, err)
+
+			updated, removed := deltaSummary(response)
+			assert.DeepEqual(t, updated, []string{"brake_v1_cluster", "local_brake_v1_cluster"})
+			assert.DeepEqual(t, removed, []string{})
+
+			assert.NilError(t, stream.Send(&discovery.DeltaDiscoveryRequest{
+				TypeUrl:       resource.ClusterType,
+				ResponseNonce: response.Nonce,
+			}))
+
+			// subsequent responses only carry the clusters that changed
+			x.ChannelServices() <- test.services()
+
+			response, err = stream.Recv()
+			assert.NilError(t, err)
+
+			updated, removed = deltaSummary(response)
+			assert.DeepEqual(t, updated, test.wantUpdated)
+			assert.DeepEqual(t, removed, test.wantRemoved)
+		})
+	}
+}
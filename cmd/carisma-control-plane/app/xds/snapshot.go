@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/proto"
+	"strings"
+)
+
+// resourceVersion encodes the version of the resources of one type together with the hash of their content.
+type resourceVersion struct {
+	version string
+	hash    string
+}
+
+// hashResources hashes the provided resources independent of their order.
+func hashResources(resources []types.Resource) (string, error) {
+	sorted := slices.Clone(resources)
+	slices.SortFunc(sorted, func(a, b types.Resource) int {
+		return strings.Compare(cache.GetResourceName(a), cache.GetResourceName(b))
+	})
+
+	h := sha256.New()
+	for _, r := range sorted {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(r)
+		if err != nil {
+			return "", err
+		}
+
+		h.Write(b)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newSnapshot creates a snapshot for the provided node in which every resource type is versioned individually. The version of a resource type only changes
+// if its content changed, so that Envoy is not forced to process an update of, e.g., the clusters if only the endpoints changed. The returned flag indicates
+// whether any resource type changed compared to the previous snapshot of the node.
+func (x *Server) newSnapshot(nodeID string, resources map[resource.Type][]types.Resource) (*cache.Snapshot, bool, error) {
+	previous := x.resourceVersions[nodeID]
+
+	// a resource type that is no longer served changes the snapshot as well
+	changed := len(previous) != len(resources)
+
+	versions := make(map[resource.Type]resourceVersion, len(resources))
+	snapshot := &cache.Snapshot{}
+	for typ, items := range resources {
+		idx := cache.GetResponseType(typ)
+		if idx == types.UnknownType {
+			return nil, false, fmt.Errorf("unknown resource type: %s", typ)
+		}
+
+		hash, err := hashResources(items)
+		if err != nil {
+			return nil, false, err
+		}
+
+		v, ok := previous[typ]
+		if !ok || v.hash != hash {
+			changed = true
+
+			v = resourceVersion{
+				version: fmt.Sprintf("%v.0", x.snapshotVersion),
+				hash:    hash,
+			}
+		}
+
+		versions[typ] = v
+		snapshot.Resources[idx] = cache.NewResources(v.version, items)
+	}
+
+	// the versions are only recorded once the snapshot is served, otherwise a later identical snapshot would be skipped
+	if err := snapshot.Consistent(); err != nil {
+		return nil, false, err
+	}
+
+	x.resourceVersions[nodeID] = versions
+
+	return snapshot, changed, nil
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+// makeTestResources creates one EDS cluster per provided cluster name and one load assignment per provided endpoint
+// name, with the provided number of endpoints each.
+func makeTestResources(clusterNames []string, endpointNames []string, endpoints int) map[resource.Type][]types.Resource {
+	clusters := make([]types.Resource, 0, len(clusterNames))
+	for _, name := range clusterNames {
+		clusters = append(clusters, &cluster.Cluster{
+			Name:                 name,
+			ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_EDS},
+		})
+	}
+
+	loadAssignments := make([]types.Resource, 0, len(endpointNames))
+	for _, name := range endpointNames {
+		loadAssignments = append(loadAssignments, &endpoint.ClusterLoadAssignment{
+			ClusterName: name,
+			Endpoints:   make([]*endpoint.LocalityLbEndpoints, endpoints),
+		})
+	}
+
+	return map[resource.Type][]types.Resource{
+		resource.ClusterType:  clusters,
+		resource.EndpointType: loadAssignments,
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	// the steps build on each other, i.e., every step is compared against the versions recorded by the previous steps
+	steps := []struct {
+		name         string
+		resources    map[resource.Type][]types.Resource
+		wantErr      bool
+		wantChanged  bool
+		wantVersions map[resource.Type]string
+	}{
+		{
+			name:         "the first snapshot versions all resource types",
+			resources:    makeTestResources([]string{"brake"}, []string{"brake"}, 1),
+			wantChanged:  true,
+			wantVersions: map[resource.Type]string{resource.ClusterType: "1.0", resource.EndpointType: "1.0"},
+		},
+		{
+			name:         "identical resources keep their versions",
+			resources:    makeTestResources([]string{"brake"}, []string{"brake"}, 1),
+			wantVersions: map[resource.Type]string{resource.ClusterType: "1.0", resource.EndpointType: "1.0"},
+		},
+		{
+			name:         "only the changed resource type is versioned again",
+			resources:    makeTestResources([]string{"brake"}, []string{"brake"}, 2),
+			wantChanged:  true,
+			wantVersions: map[resource.Type]string{resource.ClusterType: "1.0", resource.EndpointType: "3.0"},
+		},
+		{
+			name:         "inconsistent snapshots do not change the recorded versions",
+			resources:    makeTestResources([]string{"brake", "media"}, []string{"brake"}, 2),
+			wantErr:      true,
+			wantVersions: map[resource.Type]string{resource.ClusterType: "1.0", resource.EndpointType: "3.0"},
+		},
+		{
+			name:         "the resources served before an inconsistent snapshot are unchanged",
+			resources:    makeTestResources([]string{"brake"}, []string{"brake"}, 2),
+			wantVersions: map[resource.Type]string{resource.ClusterType: "1.0", resource.EndpointType: "3.0"},
+		},
+		{
+			name:         "a resource type that is no longer served changes the snapshot",
+			resources:    map[resource.Type][]types.Resource{resource.ClusterType: {}},
+			wantChanged:  true,
+			wantVersions: map[resource.Type]string{resource.ClusterType: "6.0"},
+		},
+	}
+
+	x := NewServer(nil)
+
+	for i, step := range steps {
+		x.snapshotVersion = i + 1
+
+		snapshot, changed, err := x.newSnapshot(testLocalNodeID, step.resources)
+		if step.wantErr {
+			assert.Assert(t, err != nil, step.name)
+		} else {
+			assert.NilError(t, err, step.name)
+			assert.Equal(t, changed, step.wantChanged, step.name)
+
+			for typ, version := range step.wantVersions {
+				assert.Equal(t, snapshot.GetVersion(typ), version, step.name)
+			}
+		}
+
+		versions := make(map[resource.Type]string)
+		for typ, v := range x.resourceVersions[testLocalNodeID] {
+			versions[typ] = v.version
+		}
+
+		assert.DeepEqual(t, versions, step.wantVersions)
+	}
+}
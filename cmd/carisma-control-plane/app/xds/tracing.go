@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	trace "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	tracingtype "github.com/envoyproxy/go-control-plane/envoy/type/tracing/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/accesslog"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/tracing"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// The name must not end with "_cluster", so that the collector is not mistaken for a bundle.
+	traceCollectorClusterName = "trace_collector"
+	callerTag                 = "carisma.caller"
+)
+
+// makeTraceCollectorCluster creates the cluster of the trace collector of the control plane, which is reached via the
+// same host as the xDS server.
+func makeTraceCollectorCluster(cfg *config.Config) *cluster.Cluster {
+	return &cluster.Cluster{
+		Name:                 traceCollectorClusterName,
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		DnsLookupFamily:      cluster.Cluster_V4_ONLY,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: traceCollectorClusterName,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: []*endpoint.LbEndpoint{{
+					HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+						Endpoint: &endpoint.Endpoint{
+							Address: &core.Address{
+								Address: &core.Address_SocketAddress{
+									SocketAddress: &core.SocketAddress{
+										Protocol: core.SocketAddress_TCP,
+										Address:  cfg.CentralNodeHostname,
+										PortSpecifier: &core.SocketAddress_PortValue{
+											PortValue: uint32(cfg.TraceCollectorPort),
+										},
+									},
+								},
+							},
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// makeTracing creates the tracing configuration of an HTTP connection manager. Envoy propagates the trace context via
+// the B3 headers, thus the bundles only have to forward these headers from incoming to outgoing requests to join the
+// spans of a call chain into one trace. The ingress and egress listeners report their spans independently, so that the
+// span of the calling node and the span of the called node do not share their span ID.
+func makeTracing(cfg *config.Config) (*hcm.HttpConnectionManager_Tracing, error) {
+	zipkinConfig, err := anypb.New(&trace.ZipkinConfig{
+		CollectorCluster:         traceCollectorClusterName,
+		CollectorEndpoint:        tracing.SpansPath,
+		CollectorEndpointVersion: trace.ZipkinConfig_HTTP_JSON,
+		TraceId_128Bit:           true,
+		SharedSpanContext:        wrapperspb.Bool(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpConnectionManager_Tracing{
+		RandomSampling: &typev3.Percent{Value: float64(cfg.TraceSamplingPercent)},
+		CustomTags: []*tracingtype.CustomTag{{
+			Tag: callerTag,
+			Type: &tracingtype.CustomTag_RequestHeader{
+				RequestHeader: &tracingtype.CustomTag_Header{Name: accesslog.HeaderCaller},
+			},
+		}},
+		Provider: &trace.Tracing_Http{
+			Name:       wellknown.Zipkin,
+			ConfigType: &trace.Tracing_Http_TypedConfig{TypedConfig: zipkinConfig},
+		},
+	}, nil
+}
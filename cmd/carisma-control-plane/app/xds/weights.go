@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// Header that carries the bundle version selected by the calling node to the ingress listener of the called node.
+	bundleVersionHeader = "x-carisma-bundle-version"
+)
+
+// versionWeights returns the traffic weights of the provided versions of a bundle. Without configured weights, or if
+// none of the provided versions has a weight, the traffic is split equally between the versions.
+func (x *Server) versionWeights(bundleID string, versions []string) map[string]uint32 {
+	weights := make(map[string]uint32, len(versions))
+
+	var total uint32
+	for _, version := range versions {
+		weights[version] = x.weights[bundleID][version]
+		total += weights[version]
+	}
+
+	if total == 0 {
+		for _, version := range versions {
+			weights[version] = 1
+		}
+	}
+
+	return weights
+}
+
+// makeWeightedClusters splits the traffic to a bundle between the provided clusters, which are keyed by the bundle
+// version they serve. Each cluster tells the called node which version was selected.
+func (x *Server) makeWeightedClusters(bundleID string, versionClusters map[string]string) []*route.WeightedCluster_ClusterWeight {
+	versions := maps.Keys(versionClusters)
+	slices.Sort(versions)
+
+	weights := x.versionWeights(bundleID, versions)
+
+	clusters := make([]*route.WeightedCluster_ClusterWeight, 0, len(versions))
+	for _, version := range versions {
+		if weights[version] == 0 {
+			continue
+		}
+
+		c := &route.WeightedCluster_ClusterWeight{
+			Name:   versionClusters[version],
+			Weight: wrapperspb.UInt32(weights[version]),
+		}
+
+		if version != "" {
+			c.RequestHeadersToAdd = []*core.HeaderValueOption{{
+				Header: &core.HeaderValue{
+					Key:   bundleVersionHeader,
+					Value: version,
+				},
+				AppendAction: core.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+			}}
+		}
+
+		clusters = append(clusters, c)
+	}
+
+	return clusters
+}
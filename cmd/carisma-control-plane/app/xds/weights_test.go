@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package xds
+
+import (
+	"fmt"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
+	"gotest.tools/v3/assert"
+	"strings"
+	"testing"
+)
+
+// clusterSummary encodes the cluster a route forwards to as "<cluster>" and weighted clusters as "<cluster>=<weight>",
+// each followed by the bundle version announced to the called node.
+func clusterSummary(r *route.Route) string {
+	versionHeader := func(headers []*core.HeaderValueOption) string {
+		for _, h := range headers {
+			if h.Header.Key == bundleVersionHeader {
+				return " " + h.Header.Value
+			}
+		}
+
+		return ""
+	}
+
+	action := r.GetRoute()
+	if action.GetCluster() != "" {
+		return action.GetCluster() + versionHeader(r.RequestHeadersToAdd)
+	}
+
+	clusters := make([]string, 0, len(action.GetWeightedClusters().GetClusters()))
+	for _, c := range action.GetWeightedClusters().GetClusters() {
+		clusters = append(clusters, fmt.Sprintf("%v=%v%v", c.Name, c.Weight.GetValue(), versionHeader(c.RequestHeadersToAdd)))
+	}
+
+	return strings.Join(clusters, ", ")
+}
+
+func TestMakeRoutesWeightedClusters(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		weights  map[string]uint32
+		want     []string
+	}{
+		{
+			name:     "a single version is routed to directly",
+			versions: []string{"v1"},
+			want:     []string{"brake_v1_cluster v1"},
+		},
+		{
+			name:     "without weights the traffic is split equally",
+			versions: []string{"v1", "v2"},
+			want:     []string{"brake_v1_cluster=1 v1, brake_v2_cluster=1 v2"},
+		},
+		{
+			name:     "only zero weights split the traffic equally",
+			versions: []string{"v1", "v2"},
+			weights:  map[string]uint32{"v1": 0, "v2": 0},
+			want:     []string{"brake_v1_cluster=1 v1, brake_v2_cluster=1 v2"},
+		},
+		{
+			name:     "weights of versions that are not running are ignored",
+			versions: []string{"v1", "v2"},
+			weights:  map[string]uint32{"v3": 100},
+			want:     []string{"brake_v1_cluster=1 v1, brake_v2_cluster=1 v2"},
+		},
+		{
+			name:     "a single weighted version is routed to directly",
+			versions: []string{"v1", "v2"},
+			weights:  map[string]uint32{"v1": 0, "v2": 100},
+			want:     []string{"brake_v2_cluster v2"},
+		},
+		{
+			name:     "multiple weights split the traffic",
+			versions: []string{"v1", "v2", "v3"},
+			weights:  map[string]uint32{"v1": 70, "v2": 20, "v3": 10},
+			want:     []string{"brake_v1_cluster=70 v1, brake_v2_cluster=20 v2, brake_v3_cluster=10 v3"},
+		},
+		{
+			name:     "versions without weight receive no traffic",
+			versions: []string{"v1", "v2", "v3"},
+			weights:  map[string]uint32{"v1": 90, "v3": 10},
+			want:     []string{"brake_v1_cluster=90 v1, brake_v3_cluster=10 v3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// every version runs on a remote node of its own
+			nodeIDs := []string{testRemoteNodeID1, testRemoteNodeID2, testLocalNodeID}
+			services := make(registry.ServiceConfigSnapshot, len(test.versions))
+			for i, version := range test.versions {
+				services[nodeIDs[i]] = map[string]*registry.ServiceConfig{"brake": {Versions: map[string][]int32{version: {8080}}}}
+			}
+
+			x := newTestServer(services)
+			if test.weights != nil {
+				x.weights = registry.WeightSnapshot{"brake": test.weights}
+			}
+
+			resources, err := x.makeRoutes(testRemoteNodeID1, config.Default())
+			assert.NilError(t, err)
+
+			got := make([]string, 0)
+			for _, r := range resources {
+				routeConfig := r.(*route.RouteConfiguration)
+				if routeConfig.Name != qmTrafficClass.routeName {
+					continue
+				}
+
+				for _, virtualHost := range routeConfig.VirtualHosts {
+					for _, r := range virtualHost.Routes {
+						assert.Equal(t, r.Name, "brake")
+
+						got = append(got, clusterSummary(r))
+					}
+				}
+			}
+
+			assert.DeepEqual(t, got, test.want)
+		})
+	}
+}
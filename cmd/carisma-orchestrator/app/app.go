@@ -11,10 +11,13 @@ import (
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/container"
 	carismaIO "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/io"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/metrics"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
 	pbNode "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/node/v1"
 	pbService "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/registry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"io"
@@ -36,6 +39,9 @@ const (
 
 	// Delay between the messages transmitting the actual state.
 	refreshRate = 5 * time.Second
+
+	// Maximum time to wait for the deregistration of the node upon shutdown.
+	deregistrationTimeout = 5 * time.Second
 )
 
 func Run() {
@@ -45,6 +51,9 @@ func Run() {
 	logging.LogInfo("Starting CARISMA orchestrator 🚀")
 
 	cfg, err := config.New()
+	if err == nil {
+		err = cfg.ValidateControlTLS()
+	}
 	logging.LogErr(err)
 
 	if err != nil {
@@ -78,12 +87,42 @@ func Run() {
 		handleDiscovery(ctx, cfg)
 	}
 
+	if cfg.EnableMetrics {
+		go metrics.Serve(ctx, net.JoinHostPort("", strconv.Itoa(cfg.OrchestratorMetricsPort)))
+	}
+
+	if cfg.EnableHighAvailability {
+		followLeader(ctx, cfg, containerManager)
+	} else {
+		runSession(ctx, cfg, containerManager)
+	}
+
+	logging.LogInfo("Shutting down CARISMA orchestrator")
+	stop()
+}
+
+// runSession registers the node at the control plane, runs Envoy and processes the deployment configurations until the
+// provided context is done or the channel to the control plane breaks. In high availability mode, Envoy keeps running
+// with its most recent configuration once the session ends, until the session with the next leader replaces it.
+func runSession(ctx context.Context, cfg *config.Config, containerManager container.Manager) {
+	transportCredentials := insecure.NewCredentials()
+	if cfg.EnableControlTLS {
+		tlsConfig, err := pki.ClientTLSConfig(cfg.CACertFilePath, cfg.CentralNodeHostname, cfg.NodeCertFilePath, cfg.NodeKeyFilePath)
+		logging.LogErr(err)
+
+		if err != nil {
+			return
+		}
+
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
 	cpConn, err := grpc.NewClient(
 		net.JoinHostPort(
 			cfg.CentralNodeHostname,
 			strconv.Itoa(cfg.GRPCPort),
 		),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transportCredentials),
 	)
 	logging.LogErr(err)
 
@@ -95,12 +134,20 @@ func Run() {
 		logging.LogErr(cpConn.Close())
 	}()
 
+	if cfg.EnableMetrics {
+		go watchConnection(ctx, cpConn)
+	}
+
 	nodeRegClient := pbNode.NewNodeRegistryServiceClient(cpConn)
 	r, err := nodeRegClient.Register(
-		ctx,
+		metadata.NewOutgoingContext(
+			ctx,
+			metadata.Pairs(registry.HeaderAdmissionToken, cfg.AdmissionToken),
+		),
 		&pbNode.RegisterRequest{
-			Address: cfg.NodeHostname,
-			Port:    int32(cfg.IngressPort),
+			Address:  cfg.NodeHostname,
+			Port:     int32(cfg.IngressPort),
+			Hostname: cfg.NodeHostname,
 		},
 		grpc.WaitForReady(true),
 	)
@@ -112,6 +159,25 @@ func Run() {
 
 	logging.LogInfo(fmt.Sprintf("Received node ID %s during node registration", r.Id))
 
+	// the node certificate admits the node to subsequent registrations without admission token
+	if len(r.Certificate) > 0 {
+		err = pki.WriteCertificate(&pki.Certificate{CertificatePEM: r.Certificate, PrivateKeyPEM: r.PrivateKey},
+			cfg.NodeCertFilePath, cfg.NodeKeyFilePath)
+		logging.LogErr(err)
+	}
+
+	// all subsequent requests are bound to the node ID
+	nodeMD := metadata.Pairs(registry.HeaderNodeID, r.Id, registry.HeaderNodeToken, r.Token)
+
+	defer func() {
+		// the context of the orchestrator is already cancelled upon shutdown
+		deregCtx, cancel := context.WithTimeout(context.Background(), deregistrationTimeout)
+		defer cancel()
+
+		_, err := nodeRegClient.Deregister(metadata.NewOutgoingContext(deregCtx, nodeMD), &pbNode.DeregisterRequest{Id: r.Id})
+		logging.LogErr(err)
+	}()
+
 	err = runEnvoy(context.Background(), containerManager, cfg, r.Id)
 	logging.LogErr(err)
 
@@ -119,17 +185,16 @@ func Run() {
 		return
 	}
 
-	defer func() {
-		err = stopEnvoy(context.Background(), containerManager, cfg)
-		logging.LogErr(err)
-	}()
+	if !cfg.EnableHighAvailability {
+		defer func() {
+			err = stopEnvoy(context.Background(), containerManager, cfg)
+			logging.LogErr(err)
+		}()
+	}
 
 	serviceRegClient := pbService.NewServiceRegistryServiceClient(cpConn)
 	serviceRegChanClient, err := serviceRegClient.OpenChannel(
-		metadata.NewOutgoingContext(
-			ctx,
-			metadata.Pairs(registry.HeaderNodeID, r.Id),
-		),
+		metadata.NewOutgoingContext(ctx, nodeMD),
 	)
 	logging.LogErr(err)
 
@@ -137,29 +202,51 @@ func Run() {
 		return
 	}
 
+	// the container runtime emulation does not run Envoy
+	if cfg.EnableHealthReporting && !cfg.EmulateContainerRuntime {
+		go reportHealth(ctx, cfg, serviceRegClient, nodeMD)
+	}
+
+	if cfg.EnableStatsReporting && !cfg.EmulateContainerRuntime {
+		go reportStats(ctx, cfg, serviceRegClient, nodeMD)
+	}
+
 	nodeRegChanClient, err := nodeRegClient.OpenChannel(
-		metadata.NewOutgoingContext(
-			ctx,
-			metadata.Pairs(registry.HeaderNodeID, r.Id),
-		),
+		metadata.NewOutgoingContext(ctx, nodeMD),
 	)
 
 	orchestrator := newOrchestrator(
 		cfg,
 		containerManager,
-		func(bundleID string, servicePort int32) {
+		func(bundleConfig container.BundleConfig, servicePort int32) {
+			// an unsupported protocol is announced as unspecified, i.e., the bundle is treated as gRPC service
+			logging.LogErr(bundleConfig.Protocol.Validate())
+
+			// an unsupported criticality is announced as unspecified, i.e., the bundle is treated as QM bundle
+			logging.LogErr(bundleConfig.Criticality.Validate())
+
 			err := serviceRegChanClient.Send(&pbService.ServiceAnnouncement{
-				BundleId:          bundleID,
+				BundleId:          bundleConfig.BundleID,
+				BundleVersion:     bundleConfig.BundleVersion,
 				LocalPort:         servicePort,
 				RegistrationState: pbService.ServiceAnnouncement_REGISTRATION_STATE_REGISTERED,
+				TrafficPolicy:     registry.TrafficPolicyToProto(bundleConfig.TrafficPolicy),
+				Protocol:          registry.ProtocolToProto(bundleConfig.Protocol),
+				Routes:            registry.HTTPRoutesToProto(bundleConfig.Routes),
+				Ports:             registry.ServicePortsToProto(bundleConfig.Ports, bundleConfig.LocalPorts),
+				Criticality:       registry.CriticalityToProto(bundleConfig.Criticality),
+				CallerToken:       bundleConfig.CallerToken,
 			})
 			logging.LogErr(err)
 		},
-		func(bundleID string, servicePort int32) {
+		func(bundleConfig container.BundleConfig, servicePort int32) {
 			err := serviceRegChanClient.Send(&pbService.ServiceAnnouncement{
-				BundleId:          bundleID,
+				BundleId:          bundleConfig.BundleID,
+				BundleVersion:     bundleConfig.BundleVersion,
 				LocalPort:         servicePort,
 				RegistrationState: pbService.ServiceAnnouncement_REGISTRATION_STATE_UNREGISTERED,
+				Ports:             registry.ServicePortsToProto(bundleConfig.Ports, bundleConfig.LocalPorts),
+				CallerToken:       bundleConfig.CallerToken,
 			})
 			logging.LogErr(err)
 		},
@@ -278,8 +365,12 @@ func Run() {
 
 	ticker := time.NewTicker(refreshRate)
 	go func() {
+		defer ticker.Stop()
+
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case <-ticker.C:
 				currContainers, err := containerManager.Containers(ctx)
 				logging.LogErr(err)
@@ -298,6 +389,8 @@ func Run() {
 				}
 				currContainers = currContainers[:idx]
 
+				metricContainers.Set(float64(len(currContainers)))
+
 				currDeploymentConfig := container.ExtractImageList(currContainers)
 
 				images := make([]string, len(currDeploymentConfig))
@@ -350,7 +443,4 @@ func Run() {
 		err = orchestrator.process(ctx, &dplmCfg)
 		logging.LogErr(err)
 	}
-
-	logging.LogInfo("Shutting down CARISMA orchestrator")
-	stop()
 }
@@ -24,7 +24,8 @@ func handleDiscovery(ctx context.Context, cfg *config.Config) {
 		logging.LogErr(broadcaster.Close())
 	}()
 
-	if cfg.EnableCentralMode {
+	// in high availability mode, the leading control plane announces itself instead of the central node
+	if cfg.EnableCentralMode && !cfg.EnableHighAvailability {
 		go func() {
 			discoveryMsg := udp.NewBroadcastMessage(net.JoinHostPort(cfg.NodeHostname, strconv.Itoa(cfg.GRPCPort)))
 			err := broadcaster.RepeatedlyWriteMessage(
@@ -36,7 +37,7 @@ func handleDiscovery(ctx context.Context, cfg *config.Config) {
 
 			logging.LogErr(err)
 		}()
-	} else {
+	} else if !cfg.EnableCentralMode {
 		// discover central node
 		if cfg.CentralNodeHostname == "" {
 			for {
@@ -59,7 +60,7 @@ func handleDiscovery(ctx context.Context, cfg *config.Config) {
 					continue
 				}
 
-				cfg.CentralNodeHostname = decodedMessage.Hostname
+				cfg.CentralNodeHostname = decodedMessage.Host()
 
 				break
 			}
@@ -5,9 +5,11 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"github.com/docker/go-connections/nat"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/container"
+	"os"
 	"strconv"
 )
 
@@ -17,17 +19,58 @@ const (
 )
 
 func runEnvoy(ctx context.Context, containerManager container.Manager, cfg *config.Config, nodeID string) error {
-	envoyBootstrapCfg := cfg.EnvoyBootstrapConfigWithNodeID(nodeID)
+	var trustedCAPEM []byte
+	if cfg.EnableControlTLS {
+		var err error
+		trustedCAPEM, err = os.ReadFile(cfg.CACertFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	envoyBootstrapCfg := cfg.EnvoyBootstrapConfigWithNodeID(nodeID, trustedCAPEM)
+
+	portMap := make(map[nat.Port][]nat.PortBinding, 5)
+
+	// the traffic of safety-relevant bundles is served by dedicated listeners
+	for _, port := range []int{cfg.IngressPort, cfg.SafetyIngressPort} {
+		portMap[nat.Port(strconv.Itoa(port))] = []nat.PortBinding{
+			{HostPort: strconv.Itoa(port)},
+		}
+	}
 
-	portMap := make(map[nat.Port][]nat.PortBinding, 3)
-	portMap[nat.Port(strconv.Itoa(cfg.IngressPort))] = []nat.PortBinding{
-		{HostPort: strconv.Itoa(cfg.IngressPort)},
+	// Requests received by the egress listeners leave the node with its identity, thus only the local bundles may reach
+	// them. The bundles reach the host loopback interface via their network, see container.Manager.
+	for _, port := range []int{cfg.EgressPort, cfg.SafetyEgressPort} {
+		portMap[nat.Port(strconv.Itoa(port))] = []nat.PortBinding{
+			{HostIP: cfg.EgressHostIP, HostPort: strconv.Itoa(port)},
+		}
 	}
-	portMap[nat.Port(strconv.Itoa(cfg.EgressPort))] = []nat.PortBinding{
-		{HostPort: strconv.Itoa(cfg.EgressPort)},
+
+	// outside of the debug mode, the admin interface is only reachable by the orchestrator
+	adminHostIP := "127.0.0.1"
+	if cfg.EnableDebugMode {
+		adminHostIP = ""
 	}
+
 	portMap[nat.Port(strconv.Itoa(cfg.AdminPort))] = []nat.PortBinding{
-		{HostPort: strconv.Itoa(cfg.AdminPort)},
+		{HostIP: adminHostIP, HostPort: strconv.Itoa(cfg.AdminPort)},
+	}
+
+	// The listeners of TCP and UDP services are created at runtime, thus the whole port range has to be published. Like
+	// the egress ports, the service ports themselves are only reachable by the local bundles, whereas the remote nodes
+	// reach the ingress ports of the services.
+	for port := cfg.ServicePortMin; port <= cfg.ServicePortMax; port++ {
+		for _, proto := range []string{"tcp", "udp"} {
+			portMap[nat.Port(fmt.Sprintf("%d/%s", port, proto))] = []nat.PortBinding{
+				{HostIP: cfg.EgressHostIP, HostPort: strconv.Itoa(port)},
+			}
+
+			ingressPort := port + cfg.ServiceIngressPortOffset
+			portMap[nat.Port(fmt.Sprintf("%d/%s", ingressPort, proto))] = []nat.PortBinding{
+				{HostPort: strconv.Itoa(ingressPort)},
+			}
+		}
 	}
 
 	imgName, err := container.ParseFQIN(envoyContainerImageName, cfg.DefaultContainerRegistryDomain)
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	pbService "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// Maximum time to wait for the admin interface of Envoy.
+	envoyAdminTimeout = 2 * time.Second
+)
+
+// envoyClusters encodes the subset of the cluster status provided by the admin interface of Envoy that is required to
+// determine the health of the local instances.
+type envoyClusters struct {
+	ClusterStatuses []struct {
+		Name         string `json:"name"`
+		HostStatuses []struct {
+			Address struct {
+				SocketAddress struct {
+					PortValue int32 `json:"port_value"`
+				} `json:"socket_address"`
+			} `json:"address"`
+			HealthStatus struct {
+				FailedActiveHealthCheck bool `json:"failed_active_health_check"`
+				FailedOutlierCheck      bool `json:"failed_outlier_check"`
+			} `json:"health_status"`
+		} `json:"host_statuses"`
+	} `json:"cluster_statuses"`
+}
+
+// unhealthyLocalPorts returns the local ports of the instances that Envoy considers unhealthy, i.e., that failed their
+// active health check or were ejected by the outlier detection.
+func unhealthyLocalPorts(ctx context.Context, client *http.Client, cfg *config.Config) ([]int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/clusters?format=json", cfg.AdminPort), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		logging.LogErr(resp.Body.Close())
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status of Envoy admin interface: %s", resp.Status)
+	}
+
+	var clusters envoyClusters
+	if err := json.NewDecoder(resp.Body).Decode(&clusters); err != nil {
+		return nil, err
+	}
+
+	ports := make([]int32, 0)
+	for _, c := range clusters.ClusterStatuses {
+		// the clusters of the ingress listener only contain local instances
+		if !strings.HasPrefix(c.Name, "local_") {
+			continue
+		}
+
+		for _, h := range c.HostStatuses {
+			if h.HealthStatus.FailedActiveHealthCheck || h.HealthStatus.FailedOutlierCheck {
+				ports = append(ports, h.Address.SocketAddress.PortValue)
+			}
+		}
+	}
+
+	slices.Sort(ports)
+
+	return slices.Compact(ports), nil
+}
+
+// reportHealth periodically reports the local instances that Envoy considers unhealthy to the control plane.
+func reportHealth(ctx context.Context, cfg *config.Config, client pbService.ServiceRegistryServiceClient, nodeMD metadata.MD) {
+	httpClient := &http.Client{Timeout: envoyAdminTimeout}
+
+	ticker := time.NewTicker(refreshRate)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ports, err := unhealthyLocalPorts(ctx, httpClient, cfg)
+			if err != nil {
+				// Envoy might not be ready yet, thus the previous report remains valid
+				logging.DefaultLogger.Debug().Err(err).Msg("Could not retrieve health of local instances")
+
+				continue
+			}
+
+			_, err = client.ReportHealth(metadata.NewOutgoingContext(ctx, nodeMD), &pbService.HealthReport{UnhealthyPorts: ports})
+			logging.LogErr(err)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/container"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/udp"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// Maximum duration of verifying the certificate of an announced leader.
+	leaderVerificationTimeout = 5 * time.Second
+)
+
+// watchLeader forwards the announcements of the leading control plane received via UDP broadcast until the provided
+// context is done.
+func watchLeader(ctx context.Context, cfg *config.Config, leaders chan<- udp.BroadcastMessage) {
+	broadcaster, err := udp.NewBroadcastService(ctx, cfg)
+	if err != nil {
+		logging.LogErr(err)
+
+		return
+	}
+	defer func() {
+		logging.LogErr(broadcaster.Close())
+	}()
+
+	for ctx.Err() == nil {
+		msg, _, err := broadcaster.ReadPacketWithTimeout(time.Duration(cfg.UDPTimeout) * config.TimeUnit)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			continue
+		} else if err != nil {
+			logging.LogErr(err)
+
+			return
+		}
+
+		isCARISMAMessage, err := udp.IsCARISMAMessage(msg)
+		if !isCARISMAMessage || err != nil {
+			continue
+		}
+
+		decodedMessage, err := udp.DecodeBroadcastMessage(msg)
+		if err != nil {
+			logging.LogErr(err)
+
+			continue
+		}
+
+		// only the control planes announce a leadership term
+		if decodedMessage.Term == 0 {
+			continue
+		}
+
+		select {
+		case leaders <- *decodedMessage:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// verifyLeader checks whether the announced leader presents a certificate issued by the certificate authority of the
+// control plane for its hostname, as the announcements are neither authenticated nor encrypted. Without control TLS,
+// every announced leader is accepted.
+func verifyLeader(ctx context.Context, cfg *config.Config, leader udp.BroadcastMessage) error {
+	if !cfg.EnableControlTLS {
+		return nil
+	}
+
+	tlsConfig, err := pki.ClientTLSConfig(cfg.CACertFilePath, leader.Host(), cfg.NodeCertFilePath, cfg.NodeKeyFilePath)
+	if err != nil {
+		return err
+	}
+
+	// the gRPC server of the control plane only accepts clients that negotiate HTTP/2
+	tlsConfig.NextProtos = []string{"h2"}
+
+	ctx, cancel := context.WithTimeout(ctx, leaderVerificationTimeout)
+	defer cancel()
+
+	dialer := &tls.Dialer{Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(leader.Host(), strconv.Itoa(cfg.GRPCPort)))
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// followLeader runs the session with the leading control plane and replaces it once another control plane announces
+// a higher leadership term, or once the session with the current leader broke and any leader is announced. Another
+// control plane is only followed once its certificate has been verified, see verifyLeader. The node re-registers and
+// Envoy restarts with a bootstrap configuration that points to the new leader.
+func followLeader(ctx context.Context, cfg *config.Config, containerManager container.Manager) {
+	leaders := make(chan udp.BroadcastMessage)
+	go watchLeader(ctx, cfg, leaders)
+
+	defer func() {
+		err := stopEnvoy(context.Background(), containerManager, cfg)
+		logging.LogErr(err)
+	}()
+
+	var term uint64
+	for {
+		sessionCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			runSession(sessionCtx, cfg, containerManager)
+		}()
+
+		var leader udp.BroadcastMessage
+		for replace := false; !replace; {
+			select {
+			case <-ctx.Done():
+				cancel()
+				<-done
+
+				return
+			case <-done:
+				// stop the remaining activities of the session, Envoy keeps running until the next leader is announced
+				cancel()
+				done = nil
+			case leader = <-leaders:
+				if leader.Term < term {
+					continue
+				}
+
+				if leader.Host() != cfg.CentralNodeHostname {
+					if err := verifyLeader(ctx, cfg, leader); err != nil {
+						logging.DefaultLogger.Warn().Err(err).
+							Str("Leader", leader.Host()).
+							Uint64("Term", leader.Term).
+							Msg("Ignoring announced leader whose certificate could not be verified")
+
+						continue
+					}
+				}
+
+				newLeader := leader.Term > term && leader.Host() != cfg.CentralNodeHostname
+				term = leader.Term
+
+				replace = newLeader || done == nil
+			}
+		}
+
+		cancel()
+		if done != nil {
+			<-done
+		}
+
+		err := stopEnvoy(context.Background(), containerManager, cfg)
+		logging.LogErr(err)
+
+		cfg.CentralNodeHostname = leader.Host()
+
+		logging.DefaultLogger.Info().
+			Str("Leader", cfg.CentralNodeHostname).
+			Uint64("Term", term).
+			Msg("Following leading control plane")
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+var (
+	metricReconcileDuration = metrics.NewHistogram("carisma_orchestrator_reconcile_duration_seconds",
+		"The time it takes to reconcile the containers of the node with the deployment configuration.", metrics.DefaultBuckets)
+	metricPullFailures = metrics.NewCounter("carisma_orchestrator_image_pull_failures_total",
+		"The number of container images that could not be pulled or whose container could not be created.")
+	metricContainers = metrics.NewGauge("carisma_orchestrator_containers",
+		"The number of running containers managed by the orchestrator.")
+	metricReconnects = metrics.NewCounter("carisma_orchestrator_control_plane_reconnects_total",
+		"The number of times the connection to the control plane was re-established after it was lost.")
+)
+
+// watchConnection counts the reconnects of the provided connection to the control plane until the provided context is
+// done.
+func watchConnection(ctx context.Context, conn *grpc.ClientConn) {
+	connected := false
+
+	for state := conn.GetState(); ; state = conn.GetState() {
+		switch state {
+		case connectivity.Ready:
+			if connected {
+				metricReconnects.Inc()
+
+				logging.DefaultLogger.Info().Msg("Reconnected to control plane")
+			}
+
+			connected = true
+		case connectivity.Shutdown:
+			return
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+	}
+}
@@ -10,9 +10,10 @@ import (
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/container"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
 	"strings"
+	"time"
 )
 
-type regHandler func(string, int32)
+type regHandler func(container.BundleConfig, int32)
 
 const (
 	// Name prefix of containers that are not managed by the CARISMA orchestrator.
@@ -20,14 +21,15 @@ const (
 )
 
 func diff(a, b []container.Image) []container.Image {
-	memory := make(map[string]string, len(a))
+	// several versions of the same image may run side by side, e.g., during a canary rollout
+	memory := make(map[container.Image]struct{}, len(a))
 	for _, i := range a {
-		memory[i.Name] = i.Version
+		memory[i] = struct{}{}
 	}
 
 	var missing []container.Image
 	for _, i := range b {
-		if version, found := memory[i.Name]; !found || version != i.Version {
+		if _, found := memory[i]; !found {
 			missing = append(missing, i)
 		}
 	}
@@ -35,6 +37,15 @@ func diff(a, b []container.Image) []container.Image {
 	return missing
 }
 
+// withVersion falls back to the image version if the bundle does not declare its version.
+func withVersion(bundleConfig container.BundleConfig, i container.Image) container.BundleConfig {
+	if bundleConfig.BundleVersion == "" {
+		bundleConfig.BundleVersion = i.Version
+	}
+
+	return bundleConfig
+}
+
 type orchestrator struct {
 	cfg    *config.Config
 	cntMgr container.Manager
@@ -52,6 +63,8 @@ func newOrchestrator(cfg *config.Config, cntMgr container.Manager, hReg regHandl
 }
 
 func (o *orchestrator) process(ctx context.Context, dplmCfg *config.DeploymentConfig) error {
+	defer metricReconcileDuration.ObserveDuration(time.Now())
+
 	if node, ok := (*dplmCfg)[o.cfg.NodeHostname]; ok {
 		currContainers, err := o.cntMgr.Containers(ctx)
 
@@ -93,7 +106,7 @@ func (o *orchestrator) process(ctx context.Context, dplmCfg *config.DeploymentCo
 				continue
 			}
 
-			bundleID, servicePort, err := o.cntMgr.RemoveImageAndContainer(
+			bundleConfig, servicePort, err := o.cntMgr.RemoveImageAndContainer(
 				ctx,
 				fmt.Sprintf("%s:%s", i.Name, i.Version),
 				true,
@@ -121,12 +134,12 @@ func (o *orchestrator) process(ctx context.Context, dplmCfg *config.DeploymentCo
 				continue
 			}
 
-			o.hUnreg(bundleID, servicePort)
+			o.hUnreg(withVersion(bundleConfig, i), servicePort)
 		}
 
 		newImages := diff(currDeploymentConfig, newDeploymentConfig)
 		for _, i := range newImages {
-			bundleID, servicePort, err := o.cntMgr.PullImageAndCreateContainer(
+			bundleConfig, servicePort, err := o.cntMgr.PullImageAndCreateContainer(
 				ctx,
 				fmt.Sprintf("%s:%s", i.Name, i.Version),
 				nil,
@@ -135,6 +148,8 @@ func (o *orchestrator) process(ctx context.Context, dplmCfg *config.DeploymentCo
 			)
 
 			if err != nil {
+				metricPullFailures.Inc()
+
 				// Do not abort execution here, but still dump the error.
 				logging.DefaultLogger.Error().Err(err).
 					Str("image identifier", fmt.Sprintf("%s:%s", i.Name, i.Version)).
@@ -143,7 +158,7 @@ func (o *orchestrator) process(ctx context.Context, dplmCfg *config.DeploymentCo
 				continue
 			}
 
-			o.hReg(bundleID, servicePort)
+			o.hReg(withVersion(bundleConfig, i), servicePort)
 		}
 	}
 
@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	pbService "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Prefix and suffix of the names of the statistics of the bundle clusters.
+	clusterStatPrefix = "cluster."
+	clusterStatSuffix = "_cluster"
+
+	statRequests = "upstream_rq_total"
+	statErrors   = "upstream_rq_5xx"
+	statTimeouts = "upstream_rq_timeout"
+	statLatency  = "upstream_rq_time"
+
+	// Only the upstream statistics of the bundle clusters are retrieved from the admin interface.
+	clusterStatFilter = `^cluster\..+_cluster\.(upstream_rq_total|upstream_rq_5xx|upstream_rq_timeout|upstream_rq_time)$`
+)
+
+// envoyStats encodes the subset of the statistics provided by the admin interface of Envoy in JSON format that is
+// required to summarize the requests to the bundle clusters. Counters and histograms are listed side by side.
+type envoyStats struct {
+	Stats []struct {
+		Name       string `json:"name"`
+		Value      uint64 `json:"value"`
+		Histograms *struct {
+			SupportedQuantiles []float64 `json:"supported_quantiles"`
+			ComputedQuantiles  []struct {
+				Name   string `json:"name"`
+				Values []struct {
+					Cumulative *float64 `json:"cumulative"`
+				} `json:"values"`
+			} `json:"computed_quantiles"`
+		} `json:"histograms"`
+	} `json:"stats"`
+}
+
+// splitClusterStat splits the name of a statistic of a bundle cluster into the name of the cluster and the statistic.
+func splitClusterStat(name string) (string, string, bool) {
+	name, found := strings.CutPrefix(name, clusterStatPrefix)
+	if !found {
+		return "", "", false
+	}
+
+	// bundle IDs may contain dots, thus the name of the statistic is split off at the last dot
+	i := strings.LastIndex(name, ".")
+	if i < 0 || !strings.HasSuffix(name[:i], clusterStatSuffix) {
+		return "", "", false
+	}
+
+	return name[:i], name[i+1:], true
+}
+
+// clusterStats summarizes the requests to the bundle clusters of the local Envoy.
+func clusterStats(ctx context.Context, client *http.Client, cfg *config.Config) ([]*pbService.ClusterStats, error) {
+	query := url.Values{"format": {"json"}, "filter": {clusterStatFilter}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/stats?%s", cfg.AdminPort, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		logging.LogErr(resp.Body.Close())
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status of Envoy admin interface: %s", resp.Status)
+	}
+
+	var stats envoyStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+
+	clusters := make(map[string]*pbService.ClusterStats)
+	cluster := func(name string) *pbService.ClusterStats {
+		if _, ok := clusters[name]; !ok {
+			clusters[name] = &pbService.ClusterStats{Cluster: name}
+		}
+
+		return clusters[name]
+	}
+
+	for _, s := range stats.Stats {
+		if s.Histograms != nil {
+			for _, h := range s.Histograms.ComputedQuantiles {
+				name, stat, ok := splitClusterStat(h.Name)
+				if !ok || stat != statLatency {
+					continue
+				}
+
+				c := cluster(name)
+				for i, q := range s.Histograms.SupportedQuantiles {
+					if i >= len(h.Values) || h.Values[i].Cumulative == nil {
+						continue
+					}
+
+					switch q {
+					case 50:
+						c.LatencyP50Ms = *h.Values[i].Cumulative
+					case 90:
+						c.LatencyP90Ms = *h.Values[i].Cumulative
+					case 99:
+						c.LatencyP99Ms = *h.Values[i].Cumulative
+					}
+				}
+			}
+
+			continue
+		}
+
+		name, stat, ok := splitClusterStat(s.Name)
+		if !ok {
+			continue
+		}
+
+		switch stat {
+		case statRequests:
+			cluster(name).Requests = s.Value
+		case statErrors:
+			cluster(name).Errors = s.Value
+		case statTimeouts:
+			cluster(name).Timeouts = s.Value
+		}
+	}
+
+	names := maps.Keys(clusters)
+	slices.Sort(names)
+
+	summary := make([]*pbService.ClusterStats, 0, len(names))
+	for _, name := range names {
+		summary = append(summary, clusters[name])
+	}
+
+	return summary, nil
+}
+
+// reportStats periodically streams a summary of the requests to the bundle clusters of the local Envoy to the control
+// plane. A broken stream is reopened with the next report.
+func reportStats(ctx context.Context, cfg *config.Config, client pbService.ServiceRegistryServiceClient, nodeMD metadata.MD) {
+	httpClient := &http.Client{Timeout: envoyAdminTimeout}
+
+	ticker := time.NewTicker(time.Duration(cfg.StatsReportInterval) * config.TimeUnit)
+	defer ticker.Stop()
+
+	var stream pbService.ServiceRegistryService_ReportStatsClient
+	defer func() {
+		if stream != nil {
+			_, _ = stream.CloseAndRecv()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			clusters, err := clusterStats(ctx, httpClient, cfg)
+			if err != nil {
+				// Envoy might not be ready yet
+				logging.DefaultLogger.Debug().Err(err).Msg("Could not retrieve statistics of local Envoy")
+
+				continue
+			}
+
+			if stream == nil {
+				if stream, err = client.ReportStats(metadata.NewOutgoingContext(ctx, nodeMD)); err != nil {
+					logging.LogErr(err)
+
+					continue
+				}
+			}
+
+			if err := stream.Send(&pbService.StatsReport{Clusters: clusters}); err != nil {
+				logging.LogErr(err)
+
+				stream = nil
+			}
+		}
+	}
+}
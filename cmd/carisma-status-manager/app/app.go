@@ -12,6 +12,7 @@ import (
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/container"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/metrics"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/web"
 	"html/template"
 	"io/fs"
@@ -30,6 +31,9 @@ const (
 	statusPeriod   = 30 * time.Second
 )
 
+var metricWebSocketClients = metrics.NewGauge("carisma_status_manager_websocket_clients",
+	"The number of connected WebSocket clients.")
+
 type statusManager struct {
 	containerManager container.Manager
 }
@@ -108,6 +112,8 @@ func (s statusManager) sendPing(ws *websocket.Conn) {
 func (s statusManager) readMessages(ws *websocket.Conn) {
 	defer func() {
 		_ = ws.Close()
+
+		metricWebSocketClients.Dec()
 	}()
 
 	ws.SetReadLimit(maxMessageSize)
@@ -169,6 +175,8 @@ func (s statusManager) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	metricWebSocketClients.Inc()
+
 	go s.sendStatus(ws)
 	go s.readMessages(ws)
 	go s.sendPing(ws)
@@ -230,6 +238,10 @@ func Run() {
 	r.HandleFunc("/", sm.handleIndex)
 	r.HandleFunc("/ws", sm.handleWebSocket)
 
+	if cfg.EnableMetrics {
+		r.Handle(metrics.Path, metrics.Handler()).Methods(http.MethodGet)
+	}
+
 	assetDir, err := fs.Sub(web.Assets, "static")
 	if err != nil {
 		logging.DefaultLogger.Error().Err(err).Msg("")
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+// Package accesslog implements the access log service of Envoy that collects the requests between the bundles in a
+// bounded buffer and makes them queryable.
+package accesslog
+
+import (
+	"encoding/json"
+	"errors"
+	accesslogdata "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	pbALS "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Headers whose values are recorded in addition to the default properties of a request.
+	HeaderCaller     = "x-carisma-caller"
+	HeaderGRPCStatus = "grpc-status"
+
+	// Number of records returned by a query that does not specify a limit.
+	defaultQueryLimit = 100
+)
+
+// Record encodes a request between two bundles as observed by the Envoy of the calling node.
+type Record struct {
+	Time        time.Time `json:"time"`
+	SourceNode  string    `json:"sourceNode"`
+	Caller      string    `json:"caller,omitempty"`
+	Bundle      string    `json:"bundle"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	Cluster     string    `json:"cluster,omitempty"`
+	Status      uint32    `json:"status"`
+	GRPCStatus  string    `json:"grpcStatus,omitempty"`
+	LatencyMs   float64   `json:"latencyMs"`
+	ResponseErr string    `json:"responseErr,omitempty"`
+}
+
+// Query encodes the criteria of the records to return. Empty criteria match all records.
+type Query struct {
+	SourceNode string
+	Caller     string
+	Bundle     string
+	Limit      int
+}
+
+func (q Query) matches(r Record) bool {
+	return (q.SourceNode == "" || q.SourceNode == r.SourceNode) &&
+		(q.Caller == "" || q.Caller == r.Caller) &&
+		(q.Bundle == "" || q.Bundle == r.Bundle)
+}
+
+// Collector receives the access logs of all Envoys and keeps the most recent records in a ring buffer.
+type Collector struct {
+	pbALS.UnimplementedAccessLogServiceServer
+
+	mu      sync.RWMutex // protects records and next
+	records []Record
+	next    int
+	full    bool
+}
+
+// NewCollector creates a new instance of Collector that keeps up to the provided number of records.
+func NewCollector(size int) *Collector {
+	return &Collector{records: make([]Record, max(size, 1))}
+}
+
+// Add stores the provided record, replacing the oldest record if the buffer is full.
+func (c *Collector) Add(r Record) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.records[c.next] = r
+	c.next = (c.next + 1) % len(c.records)
+	if c.next == 0 {
+		c.full = true
+	}
+}
+
+// Query returns the most recent records that match the provided query, the newest record first.
+func (c *Collector) Query(q Query) []Record {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	count := c.next
+	if c.full {
+		count = len(c.records)
+	}
+
+	records := make([]Record, 0, min(limit, count))
+	for i := 1; i <= count && len(records) < limit; i++ {
+		r := c.records[(c.next-i+len(c.records))%len(c.records)]
+		if q.matches(r) {
+			records = append(records, r)
+		}
+	}
+
+	return records
+}
+
+func headerValue(headers map[string]string, key string) string {
+	if headers == nil {
+		return ""
+	}
+
+	return headers[key]
+}
+
+// recordFromEntry converts an HTTP access log entry of the provided node. Envoy names the route after the called bundle.
+func recordFromEntry(nodeID string, entry *accesslogdata.HTTPAccessLogEntry) Record {
+	common := entry.GetCommonProperties()
+	req := entry.GetRequest()
+	resp := entry.GetResponse()
+
+	grpcStatus := headerValue(resp.GetResponseTrailers(), HeaderGRPCStatus)
+	if grpcStatus == "" {
+		// gRPC responses without a body carry their status in the headers
+		grpcStatus = headerValue(resp.GetResponseHeaders(), HeaderGRPCStatus)
+	}
+
+	r := Record{
+		SourceNode: nodeID,
+		Caller:     headerValue(req.GetRequestHeaders(), HeaderCaller),
+		Bundle:     common.GetRouteName(),
+		Method:     req.GetRequestMethod().String(),
+		Path:       req.GetPath(),
+		Cluster:    common.GetUpstreamCluster(),
+		Status:     resp.GetResponseCode().GetValue(),
+		GRPCStatus: grpcStatus,
+	}
+
+	if common.GetStartTime() != nil {
+		r.Time = common.GetStartTime().AsTime()
+	}
+
+	if common.GetTimeToLastDownstreamTxByte() != nil {
+		r.LatencyMs = float64(common.GetTimeToLastDownstreamTxByte().AsDuration().Microseconds()) / 1000
+	}
+
+	if details := resp.GetResponseCodeDetails(); details != "" && details != "via_upstream" {
+		r.ResponseErr = details
+	}
+
+	return r
+}
+
+// StreamAccessLogs receives the access logs of an Envoy. Envoy only identifies its node in the first message.
+func (c *Collector) StreamAccessLogs(stream pbALS.AccessLogService_StreamAccessLogsServer) error {
+	var nodeID string
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if id := msg.GetIdentifier().GetNode().GetId(); id != "" {
+			nodeID = id
+		}
+
+		for _, entry := range msg.GetHttpLogs().GetLogEntry() {
+			c.Add(recordFromEntry(nodeID, entry))
+		}
+	}
+}
+
+func (c *Collector) handleQuery(w http.ResponseWriter, r *http.Request) {
+	q := Query{
+		SourceNode: r.URL.Query().Get("node"),
+		Caller:     r.URL.Query().Get("caller"),
+		Bundle:     r.URL.Query().Get("bundle"),
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		if q.Limit, err = strconv.Atoi(limit); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(c.Query(q))
+	logging.LogErr(err)
+}
+
+// RegisterHandlers registers the read-only HTTP API that queries the collected records, optionally filtered by the
+// source node, the caller and the called bundle.
+func (c *Collector) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /accesslogs", c.handleQuery)
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package accesslog
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestCollectorQuery(t *testing.T) {
+	c := NewCollector(3)
+
+	c.Add(Record{SourceNode: "node-hpc-1", Bundle: "navigation", Path: "/1"})
+	c.Add(Record{SourceNode: "node-hpc-2", Bundle: "navigation", Path: "/2"})
+	assert.DeepEqual(t, c.Query(Query{}), []Record{
+		{SourceNode: "node-hpc-2", Bundle: "navigation", Path: "/2"},
+		{SourceNode: "node-hpc-1", Bundle: "navigation", Path: "/1"},
+	})
+
+	// the oldest records are replaced once the buffer is full
+	c.Add(Record{SourceNode: "node-hpc-1", Bundle: "radio", Path: "/3"})
+	c.Add(Record{SourceNode: "node-hpc-1", Bundle: "navigation", Path: "/4"})
+
+	records := c.Query(Query{SourceNode: "node-hpc-1"})
+	assert.Equal(t, len(records), 2)
+	assert.Equal(t, records[0].Path, "/4")
+	assert.Equal(t, records[1].Path, "/3")
+
+	records = c.Query(Query{Bundle: "navigation", Limit: 1})
+	assert.DeepEqual(t, records, []Record{{SourceNode: "node-hpc-1", Bundle: "navigation", Path: "/4"}})
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AnySource denotes all bundles as source of an authorization policy.
+const AnySource = "*"
+
+// AuthorizationPolicy allows the source bundles to call the target bundle, optionally restricted to paths with the
+// provided prefixes, e.g., "/Y.Service/Method" of a gRPC service. Once a bundle is the target of at least one policy,
+// all calls to this bundle that no policy allows are denied.
+type AuthorizationPolicy struct {
+	Name    string   `json:"name"`
+	Target  string   `json:"target"`
+	Sources []string `json:"sources"`
+	Paths   []string `json:"paths,omitempty"`
+}
+
+// AuthorizationPoliciesFromJSON converts the JSON representation of a list of authorization policies and checks
+// whether the policies are well-formed and uniquely named.
+func AuthorizationPoliciesFromJSON(j []byte) ([]AuthorizationPolicy, error) {
+	var policies []AuthorizationPolicy
+	if err := json.Unmarshal(j, &policies); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{}, len(policies))
+
+	for _, p := range policies {
+		if p.Name == "" {
+			return nil, errors.New("authorization policy name missing")
+		}
+
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("duplicate authorization policy name: %s", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		if p.Target == "" {
+			return nil, fmt.Errorf("target of authorization policy missing: %s", p.Name)
+		}
+
+		if len(p.Sources) == 0 {
+			return nil, fmt.Errorf("sources of authorization policy missing: %s", p.Name)
+		}
+
+		for _, path := range p.Paths {
+			if !strings.HasPrefix(path, "/") {
+				return nil, fmt.Errorf("authorization policy path must start with '/': %s", path)
+			}
+		}
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestAuthorizationPoliciesFromJSON(t *testing.T) {
+	fileContent := `[
+	{
+		"name": "x-calls-y",
+		"target": "y",
+		"sources": ["x"],
+		"paths": ["/Y.Service/Method"]
+	}
+]`
+
+	policies, err := AuthorizationPoliciesFromJSON([]byte(fileContent))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, policies, []AuthorizationPolicy{{
+		Name:    "x-calls-y",
+		Target:  "y",
+		Sources: []string{"x"},
+		Paths:   []string{"/Y.Service/Method"},
+	}})
+}
+
+func TestAuthorizationPoliciesFromJSONInvalid(t *testing.T) {
+	_, err := AuthorizationPoliciesFromJSON([]byte(`[{"name": "p", "target": "y", "sources": []}]`))
+	assert.ErrorContains(t, err, "sources")
+
+	_, err = AuthorizationPoliciesFromJSON([]byte(`[{"name": "p", "target": "y", "sources": ["x"]}, {"name": "p", "target": "z", "sources": ["x"]}]`))
+	assert.ErrorContains(t, err, "duplicate")
+
+	_, err = AuthorizationPoliciesFromJSON([]byte(`[{"name": "p", "target": "y", "sources": ["x"], "paths": ["Y.Service"]}]`))
+	assert.ErrorContains(t, err, "path")
+}
@@ -5,6 +5,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -40,8 +41,53 @@ type Config struct {
 	UDPTimeout                     int    `json:"udpTimeout"`
 	IngressPort                    int    `json:"ingressPort"`
 	EgressPort                     int    `json:"egressPort"`
+	EgressHostIP                   string `json:"egressHostIP"`
 	AdminPort                      int    `json:"adminPort"`
 	DefaultContainerRegistryDomain string `json:"defaultContainerRegistryDomain"`
+	RegistryFilePath               string `json:"registryFile"`
+	NodeGracePeriod                int    `json:"nodeGracePeriod"`
+	NodeSuspectTimeout             int    `json:"nodeSuspectTimeout"`
+	NodeDeadTimeout                int    `json:"nodeDeadTimeout"`
+	EnableDeltaXDS                 bool   `json:"enableDeltaXDS"`
+	EnableMTLS                     bool   `json:"enableMTLS"`
+	CACertFilePath                 string `json:"caCertFile"`
+	CAKeyFilePath                  string `json:"caKeyFile"`
+	NodeCertTTL                    int    `json:"nodeCertTTL"`
+	EnableControlTLS               bool   `json:"enableControlTLS"`
+	AdmissionToken                 string `json:"admissionToken"`
+	NodeCertFilePath               string `json:"nodeCertFile"`
+	NodeKeyFilePath                string `json:"nodeKeyFile"`
+	SessionKeyFilePath             string `json:"sessionKeyFile"`
+	ServicePortMin                 int    `json:"servicePortMin"`
+	ServicePortMax                 int    `json:"servicePortMax"`
+	ServiceIngressPortOffset       int    `json:"serviceIngressPortOffset"`
+	EnableHealthReporting          bool   `json:"enableHealthReporting"`
+	AuthorizationPolicyFilePath    string `json:"authorizationPolicyFile"`
+	RateLimitPolicyFilePath        string `json:"rateLimitPolicyFile"`
+	EnableGlobalRateLimit          bool   `json:"enableGlobalRateLimit"`
+	MaxFaultDuration               int    `json:"maxFaultDuration"`
+	IntrospectionPort              int    `json:"introspectionPort"`
+	EnableXDSPeerValidation        bool   `json:"enableXDSPeerValidation"`
+	EnableAccessLog                bool   `json:"enableAccessLog"`
+	AccessLogBufferSize            int    `json:"accessLogBufferSize"`
+	EnableTracing                  bool   `json:"enableTracing"`
+	TraceSamplingPercent           int    `json:"traceSamplingPercent"`
+	TraceCollectorPort             int    `json:"traceCollectorPort"`
+	TraceBufferSize                int    `json:"traceBufferSize"`
+	EnableMetrics                  bool   `json:"enableMetrics"`
+	ControlPlaneMetricsPort        int    `json:"controlPlaneMetricsPort"`
+	OrchestratorMetricsPort        int    `json:"orchestratorMetricsPort"`
+	EnableStatsReporting           bool   `json:"enableStatsReporting"`
+	StatsReportInterval            int    `json:"statsReportInterval"`
+	EnableHighAvailability         bool   `json:"enableHighAvailability"`
+	ControlPlanePeer               string `json:"controlPlanePeer"`
+	EnableStandbyMode              bool   `json:"enableStandbyMode"`
+	ReplicationInterval            int    `json:"replicationInterval"`
+	LeaderTimeout                  int    `json:"leaderTimeout"`
+	SafetyIngressPort              int    `json:"safetyIngressPort"`
+	SafetyEgressPort               int    `json:"safetyEgressPort"`
+	QMConnectionLimit              int    `json:"qmConnectionLimit"`
+	EnvoyMaxHeapSize               int    `json:"envoyMaxHeapSize"`
 }
 
 // New creates a new instance of Config based on default values. The default values can be overwritten by actual values specified in a file representation of the struct or by
@@ -88,8 +134,53 @@ func Default() *Config {
 		UDPTimeout:                     60,
 		IngressPort:                    8000,
 		EgressPort:                     9000,
+		EgressHostIP:                   "127.0.0.1",
 		AdminPort:                      9901,
 		DefaultContainerRegistryDomain: "docker.io",
+		RegistryFilePath:               "/opt/carisma/data/registry.json",
+		NodeGracePeriod:                30,
+		NodeSuspectTimeout:             10,
+		NodeDeadTimeout:                20,
+		EnableDeltaXDS:                 true,
+		EnableMTLS:                     true,
+		CACertFilePath:                 "/opt/carisma/data/ca.pem",
+		CAKeyFilePath:                  "/opt/carisma/data/private/ca-key.pem",
+		NodeCertTTL:                    86400,
+		EnableControlTLS:               true,
+		AdmissionToken:                 "",
+		NodeCertFilePath:               "/opt/carisma/conf/node.pem",
+		NodeKeyFilePath:                "/opt/carisma/conf/node-key.pem",
+		SessionKeyFilePath:             "/opt/carisma/data/private/session.key",
+		ServicePortMin:                 20000,
+		ServicePortMax:                 20049,
+		ServiceIngressPortOffset:       1000,
+		EnableHealthReporting:          true,
+		AuthorizationPolicyFilePath:    "/opt/carisma/conf/authorization_policies.json",
+		RateLimitPolicyFilePath:        "/opt/carisma/conf/rate_limit_policies.json",
+		EnableGlobalRateLimit:          false,
+		MaxFaultDuration:               3600,
+		IntrospectionPort:              8017,
+		EnableXDSPeerValidation:        true,
+		EnableAccessLog:                true,
+		AccessLogBufferSize:            10000,
+		EnableTracing:                  true,
+		TraceSamplingPercent:           1,
+		TraceCollectorPort:             9411,
+		TraceBufferSize:                1000,
+		EnableMetrics:                  true,
+		ControlPlaneMetricsPort:        8018,
+		OrchestratorMetricsPort:        8019,
+		EnableStatsReporting:           true,
+		StatsReportInterval:            30,
+		EnableHighAvailability:         false,
+		ControlPlanePeer:               "",
+		EnableStandbyMode:              false,
+		ReplicationInterval:            2,
+		LeaderTimeout:                  10,
+		SafetyIngressPort:              8001,
+		SafetyEgressPort:               9001,
+		QMConnectionLimit:              1000,
+		EnvoyMaxHeapSize:               512,
 	}
 }
 
@@ -116,8 +207,72 @@ func (c *Config) applyCommandlineFlags() {
 	flag.IntVar(&c.UDPTimeout, "udp-timeout", c.UDPTimeout, "The maximum time to wait for an UDP broadcast message")
 	flag.IntVar(&c.IngressPort, "ingress-port", c.IngressPort, "The ingress port for Envoy to listen on")
 	flag.IntVar(&c.EgressPort, "egress-port", c.EgressPort, "The egress port for Envoy to listen on")
+	flag.StringVar(&c.EgressHostIP, "egress-host-ip", c.EgressHostIP,
+		"The host IP the egress ports of Envoy are published on, which must only be reachable by the local bundles")
 	flag.StringVar(&c.DefaultContainerRegistryDomain, "default-container-registry-domain", c.DefaultContainerRegistryDomain,
 		"The default container registry domain to be used for normalizing image names")
+	flag.StringVar(&c.RegistryFilePath, "registry-file", c.RegistryFilePath, "The file the control plane persists the node and service registry in")
+	flag.IntVar(&c.NodeGracePeriod, "node-grace-period", c.NodeGracePeriod, "The time to wait for a disconnected node to reconnect before evicting it")
+	flag.IntVar(&c.NodeSuspectTimeout, "node-suspect-timeout", c.NodeSuspectTimeout, "The time without heartbeat after which a node is considered suspect")
+	flag.IntVar(&c.NodeDeadTimeout, "node-dead-timeout", c.NodeDeadTimeout, "The time without heartbeat after which a node is considered dead")
+	flag.BoolVar(&c.EnableDeltaXDS, "enable-delta-xds", c.EnableDeltaXDS, "Let Envoy subscribe to incremental (delta) xDS updates")
+	flag.BoolVar(&c.EnableMTLS, "enable-mtls", c.EnableMTLS, "Enforce mutual TLS for the traffic between the nodes")
+	flag.StringVar(&c.CACertFilePath, "ca-cert-file", c.CACertFilePath, "The file the control plane persists the root certificate of its certificate authority in")
+	flag.StringVar(&c.CAKeyFilePath, "ca-key-file", c.CAKeyFilePath, "The file the control plane persists the private key of its certificate authority in")
+	flag.IntVar(&c.NodeCertTTL, "node-cert-ttl", c.NodeCertTTL, "The validity of the certificates issued to the nodes")
+	flag.BoolVar(&c.EnableControlTLS, "enable-control-tls", c.EnableControlTLS,
+		"Protect the gRPC control channel with TLS, the root certificate of the control plane has to be present at the CA certificate file")
+	flag.StringVar(&c.AdmissionToken, "admission-token", c.AdmissionToken,
+		"The pre-shared token that admits nodes without client certificate to register, required if control TLS is enabled")
+	flag.StringVar(&c.NodeCertFilePath, "node-cert-file", c.NodeCertFilePath, "The client certificate the node authenticates itself with at the control plane, if present")
+	flag.StringVar(&c.NodeKeyFilePath, "node-key-file", c.NodeKeyFilePath, "The private key of the client certificate of the node")
+	flag.StringVar(&c.SessionKeyFilePath, "session-key-file", c.SessionKeyFilePath, "The file the control plane persists the key for signing node tokens in")
+	flag.IntVar(&c.ServicePortMin, "service-port-min", c.ServicePortMin, "The lowest port a TCP or UDP service may be exposed on")
+	flag.IntVar(&c.ServicePortMax, "service-port-max", c.ServicePortMax, "The highest port a TCP or UDP service may be exposed on")
+	flag.IntVar(&c.ServiceIngressPortOffset, "service-ingress-port-offset", c.ServiceIngressPortOffset,
+		"The offset between the port of a TCP or UDP service and the port remote nodes reach its instances on")
+	flag.BoolVar(&c.EnableHealthReporting, "enable-health-reporting", c.EnableHealthReporting,
+		"Report the health of the local instances observed by Envoy to the control plane, requires the Envoy admin interface")
+	flag.StringVar(&c.AuthorizationPolicyFilePath, "authorization-policy-file", c.AuthorizationPolicyFilePath,
+		"The file the control plane loads the authorization policies between the bundles from")
+	flag.StringVar(&c.RateLimitPolicyFilePath, "rate-limit-policy-file", c.RateLimitPolicyFilePath,
+		"The file the control plane loads the rate limits of the bundles from, which take precedence over the declared ones")
+	flag.BoolVar(&c.EnableGlobalRateLimit, "enable-global-rate-limit", c.EnableGlobalRateLimit,
+		"Run the rate limit service in the control plane that enforces the global rate limits of the bundles across all nodes")
+	flag.IntVar(&c.MaxFaultDuration, "max-fault-duration", c.MaxFaultDuration, "The maximum time an injected fault remains active before it expires")
+	flag.IntVar(&c.IntrospectionPort, "introspection-port", c.IntrospectionPort,
+		"The port of the read-only HTTP API that presents the xDS snapshots, only reachable from localhost unless debug mode is enabled")
+	flag.BoolVar(&c.EnableXDSPeerValidation, "enable-xds-peer-validation", c.EnableXDSPeerValidation,
+		"Reject Envoys whose address does not match the registered host of their node, disable if the nodes reach the control plane via NAT")
+	flag.BoolVar(&c.EnableAccessLog, "enable-access-log", c.EnableAccessLog, "Stream the requests between the bundles to the access log collector of the control plane")
+	flag.IntVar(&c.AccessLogBufferSize, "access-log-buffer-size", c.AccessLogBufferSize, "The number of most recent requests kept by the access log collector")
+	flag.BoolVar(&c.EnableTracing, "enable-tracing", c.EnableTracing, "Let the Envoys report the spans of the requests between the bundles to the trace collector of the control plane")
+	flag.IntVar(&c.TraceSamplingPercent, "trace-sampling-percent", c.TraceSamplingPercent,
+		"The percentage of requests without a sampling decision of the caller that are traced")
+	flag.IntVar(&c.TraceCollectorPort, "trace-collector-port", c.TraceCollectorPort, "The port of the Zipkin-compatible trace collector of the control plane")
+	flag.IntVar(&c.TraceBufferSize, "trace-buffer-size", c.TraceBufferSize, "The number of most recent traces kept by the trace collector")
+	flag.BoolVar(&c.EnableMetrics, "enable-metrics", c.EnableMetrics, "Export metrics in the Prometheus text format")
+	flag.IntVar(&c.ControlPlaneMetricsPort, "control-plane-metrics-port", c.ControlPlaneMetricsPort, "The port the control plane serves its metrics on")
+	flag.IntVar(&c.OrchestratorMetricsPort, "orchestrator-metrics-port", c.OrchestratorMetricsPort, "The port the orchestrator serves its metrics on")
+	flag.BoolVar(&c.EnableStatsReporting, "enable-stats-reporting", c.EnableStatsReporting,
+		"Report a summary of the upstream statistics of the local Envoy to the control plane")
+	flag.IntVar(&c.StatsReportInterval, "stats-report-interval", c.StatsReportInterval, "The time between two statistics reports of a node")
+	flag.BoolVar(&c.EnableHighAvailability, "enable-high-availability", c.EnableHighAvailability,
+		"Run an active and a standby control plane, the nodes follow the leader announced via UDP broadcast")
+	flag.StringVar(&c.ControlPlanePeer, "control-plane-peer", c.ControlPlanePeer, "The hostname of the peer control plane in high availability mode")
+	flag.BoolVar(&c.EnableStandbyMode, "enable-standby-mode", c.EnableStandbyMode,
+		"Start the control plane as standby that replicates the state of its peer until the peer fails")
+	flag.IntVar(&c.ReplicationInterval, "replication-interval", c.ReplicationInterval,
+		"The maximum time between two replications of the state to the standby control plane")
+	flag.IntVar(&c.LeaderTimeout, "leader-timeout", c.LeaderTimeout,
+		"The time without replication after which the standby control plane takes over")
+	flag.IntVar(&c.SafetyIngressPort, "safety-ingress-port", c.SafetyIngressPort,
+		"The ingress port for Envoy to listen on for the traffic to safety-relevant bundles")
+	flag.IntVar(&c.SafetyEgressPort, "safety-egress-port", c.SafetyEgressPort,
+		"The egress port for Envoy to listen on for the traffic of safety-relevant bundles")
+	flag.IntVar(&c.QMConnectionLimit, "qm-connection-limit", c.QMConnectionLimit,
+		"The maximum number of downstream connections of the listeners for QM traffic, the safety-relevant listeners are not limited")
+	flag.IntVar(&c.EnvoyMaxHeapSize, "envoy-max-heap-size", c.EnvoyMaxHeapSize, "The heap size in MiB the overload manager of Envoy relieves the memory pressure at")
 
 	flag.Parse()
 }
@@ -128,8 +283,25 @@ func (c *Config) fix() {
 	}
 }
 
-// EnvoyBootstrapConfigWithNodeID creates a configuration for the Envoy proxy based on the encoded configuration values.
-func (c *Config) EnvoyBootstrapConfigWithNodeID(nodeID string) string {
+// ValidateControlTLS rejects a control TLS configuration that prevents the nodes from joining the mesh. It is checked by
+// the control plane and the orchestrator, which use the control channel.
+func (c *Config) ValidateControlTLS() error {
+	// nodes only receive their client certificate with their first registration, which requires the admission token
+	if c.EnableControlTLS && c.AdmissionToken == "" {
+		return errors.New("control TLS requires an admission token, configure the same admission token on all nodes")
+	}
+
+	return nil
+}
+
+// EnvoyBootstrapConfigWithNodeID creates a configuration for the Envoy proxy based on the encoded configuration values. If a root certificate is
+// provided, Envoy connects to the control plane via TLS and only trusts certificates issued by it.
+func (c *Config) EnvoyBootstrapConfigWithNodeID(nodeID string, trustedCAPEM []byte) string {
+	adsAPIType := core.ApiConfigSource_GRPC
+	if c.EnableDeltaXDS {
+		adsAPIType = core.ApiConfigSource_DELTA_GRPC
+	}
+
 	bootstrapCfg := bootstrap.Bootstrap{
 		Node: &core.Node{
 			Id:      nodeID,
@@ -149,7 +321,7 @@ func (c *Config) EnvoyBootstrapConfigWithNodeID(nodeID string) string {
 				ResourceApiVersion: core.ApiVersion_V3,
 			},
 			AdsConfig: &core.ApiConfigSource{
-				ApiType: core.ApiConfigSource_GRPC,
+				ApiType: adsAPIType,
 				GrpcServices: []*core.GrpcService{
 					{
 						TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
@@ -200,7 +372,15 @@ func (c *Config) EnvoyBootstrapConfigWithNodeID(nodeID string) string {
 		},
 	}
 
-	if c.EnableDebugMode {
+	// the safety-relevant traffic is not subject to the connection limit, see the listeners created by the control plane
+	bootstrapCfg.OverloadManager = OverloadManager(uint64(c.EnvoyMaxHeapSize)<<20, int64(c.QMConnectionLimit))
+
+	if len(trustedCAPEM) > 0 {
+		bootstrapCfg.StaticResources.Clusters[0].TransportSocket = ControlPlaneTransportSocket(trustedCAPEM, c.CentralNodeHostname)
+	}
+
+	// the orchestrator retrieves the health of the local instances and the statistics via the admin interface
+	if c.EnableDebugMode || c.EnableHealthReporting || c.EnableStatsReporting {
 		bootstrapCfg.Admin = &bootstrap.Admin{
 			Address: &core.Address{
 				Address: &core.Address_SocketAddress{
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"flag"
 	"gotest.tools/v3/assert"
 	"os"
 	"testing"
@@ -97,3 +98,32 @@ func TestCommandLineArgumentsForSatelliteNode(t *testing.T) {
 
 	assert.DeepEqual(t, *cfg, *expectation)
 }
+
+func TestValidateControlTLS(t *testing.T) {
+	cfg := Default()
+	assert.ErrorContains(t, cfg.ValidateControlTLS(), "admission token")
+
+	cfg.AdmissionToken = "secret"
+	assert.NilError(t, cfg.ValidateControlTLS())
+
+	cfg = Default()
+	cfg.EnableControlTLS = false
+	assert.NilError(t, cfg.ValidateControlTLS())
+}
+
+func TestNewWithDefaults(t *testing.T) {
+	args, commandLine := os.Args, flag.CommandLine
+	t.Cleanup(func() {
+		os.Args, flag.CommandLine = args, commandLine
+	})
+
+	// the flags are registered on a fresh command line, as other tests already registered them
+	os.Args = []string{"cmd"}
+	flag.CommandLine = flag.NewFlagSet(args[0], flag.ContinueOnError)
+
+	// every daemon starts with the default configuration, the control TLS is only validated where it is used
+	cfg, err := New()
+	assert.NilError(t, err)
+	assert.Equal(t, cfg.EnableControlTLS, Default().EnableControlTLS)
+	assert.Equal(t, cfg.AdmissionToken, "")
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"fmt"
+)
+
+// Criticality encodes the criticality class of a bundle following the automotive safety integrity levels (ASIL) of
+// ISO 26262. Bundles without declared criticality are quality managed (QM).
+type Criticality string
+
+const (
+	// CriticalityQM denotes bundles without safety requirements, e.g., infotainment functions.
+	CriticalityQM Criticality = "qm"
+	// CriticalityASILA denotes safety-relevant bundles of ASIL A.
+	CriticalityASILA Criticality = "asil-a"
+	// CriticalityASILB denotes safety-relevant bundles of ASIL B.
+	CriticalityASILB Criticality = "asil-b"
+	// CriticalityASILC denotes safety-relevant bundles of ASIL C.
+	CriticalityASILC Criticality = "asil-c"
+	// CriticalityASILD denotes safety-relevant bundles of ASIL D.
+	CriticalityASILD Criticality = "asil-d"
+)
+
+// Validate checks whether the criticality class is supported.
+func (c Criticality) Validate() error {
+	switch c {
+	case "", CriticalityQM, CriticalityASILA, CriticalityASILB, CriticalityASILC, CriticalityASILD:
+		return nil
+	default:
+		return fmt.Errorf("unsupported criticality: %s", c)
+	}
+}
+
+// SafetyRelevant checks whether the criticality class is one of the ASIL classes. The traffic of safety-relevant
+// bundles is separated from the traffic of QM bundles, all ASIL classes share the same traffic class.
+func (c Criticality) SafetyRelevant() bool {
+	switch c {
+	case CriticalityASILA, CriticalityASILB, CriticalityASILC, CriticalityASILD:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestCriticality(t *testing.T) {
+	assert.NilError(t, Criticality("").Validate())
+	assert.NilError(t, CriticalityASILD.Validate())
+	assert.ErrorContains(t, Criticality("asil-e").Validate(), "unsupported criticality")
+
+	assert.Assert(t, !Criticality("").SafetyRelevant())
+	assert.Assert(t, !CriticalityQM.SafetyRelevant())
+	assert.Assert(t, CriticalityASILB.SafetyRelevant())
+}
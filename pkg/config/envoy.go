@@ -5,8 +5,31 @@ package config
 
 import (
 	"fmt"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	overload "github.com/envoyproxy/go-control-plane/envoy/config/overload/v3"
+	downstreamconnections "github.com/envoyproxy/go-control-plane/envoy/extensions/resource_monitors/downstream_connections/v3"
+	fixedheap "github.com/envoyproxy/go-control-plane/envoy/extensions/resource_monitors/fixed_heap/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	upstreams "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"time"
+)
+
+const (
+	fixedHeapMonitorName             = "envoy.resource_monitors.fixed_heap"
+	downstreamConnectionsMonitorName = "envoy.resource_monitors.global_downstream_max_connections"
+
+	// Heap pressure at which Envoy returns unused memory to the system.
+	shrinkHeapThreshold = 0.90
+	// Heap pressure range across which the idle timeouts of downstream connections are reduced to their minimum.
+	reduceTimeoutsScalingThreshold    = 0.85
+	reduceTimeoutsSaturationThreshold = 0.95
+	minIdleTimeoutScalePercent        = 20
+
+	overloadRefreshInterval = 250 * time.Millisecond
 )
 
 // HTTP2ProtocolOptions creates the HTTP2 protocol options required to enable gRPC within Envoy.
@@ -27,3 +50,119 @@ func HTTP2ProtocolOptions() map[string]*anypb.Any {
 		"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": a,
 	}
 }
+
+// ControlPlaneTransportSocket creates the transport socket that connects Envoy to the control plane via TLS. Only the
+// control plane is authenticated, i.e., its certificate has to be issued by the provided root certificate.
+func ControlPlaneTransportSocket(trustedCAPEM []byte, serverName string) *core.TransportSocket {
+	a, err := anypb.New(
+		&tls.UpstreamTlsContext{
+			Sni: serverName,
+			CommonTlsContext: &tls.CommonTlsContext{
+				ValidationContextType: &tls.CommonTlsContext_ValidationContext{
+					ValidationContext: &tls.CertificateValidationContext{
+						TrustedCa: &core.DataSource{
+							Specifier: &core.DataSource_InlineBytes{InlineBytes: trustedCAPEM},
+						},
+					},
+				},
+				AlpnProtocols: []string{"h2"},
+			},
+		})
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct control plane transport socket: %s", err))
+	}
+
+	return &core.TransportSocket{
+		Name:       wellknown.TransportSocketTLS,
+		ConfigType: &core.TransportSocket_TypedConfig{TypedConfig: a},
+	}
+}
+
+// HTTP1ProtocolOptions creates the HTTP/1.1 protocol options required to forward plain HTTP requests, including
+// WebSocket upgrades, within Envoy.
+func HTTP1ProtocolOptions() map[string]*anypb.Any {
+	a, err := anypb.New(
+		&upstreams.HttpProtocolOptions{
+			UpstreamProtocolOptions: &upstreams.HttpProtocolOptions_ExplicitHttpConfig_{
+				ExplicitHttpConfig: &upstreams.HttpProtocolOptions_ExplicitHttpConfig{
+					ProtocolConfig: &upstreams.HttpProtocolOptions_ExplicitHttpConfig_HttpProtocolOptions{},
+				},
+			},
+		})
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct http1 protocol options: %s", err))
+	}
+
+	return map[string]*anypb.Any{
+		"envoy.extensions.upstreams.http.v3.HttpProtocolOptions": a,
+	}
+}
+
+// OverloadManager creates the overload manager of Envoy. The number of downstream connections is limited to the provided
+// maximum, which only applies to the listeners that do not ignore the global connection limit, i.e., the listeners for
+// QM traffic. Once the heap approaches the provided size, idle connections are closed sooner and unused memory is
+// returned to the system. Requests are never rejected by the overload manager, as this would affect the safety-relevant
+// traffic as well.
+func OverloadManager(maxHeapSizeBytes uint64, maxConnections int64) *overload.OverloadManager {
+	fixedHeap, err := anypb.New(&fixedheap.FixedHeapConfig{MaxHeapSizeBytes: maxHeapSizeBytes})
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct fixed heap monitor: %s", err))
+	}
+
+	downstreamConnections, err := anypb.New(&downstreamconnections.DownstreamConnectionsConfig{
+		MaxActiveDownstreamConnections: maxConnections,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct downstream connections monitor: %s", err))
+	}
+
+	scaleTimers, err := anypb.New(&overload.ScaleTimersOverloadActionConfig{
+		TimerScaleFactors: []*overload.ScaleTimersOverloadActionConfig_ScaleTimer{{
+			Timer: overload.ScaleTimersOverloadActionConfig_HTTP_DOWNSTREAM_CONNECTION_IDLE,
+			OverloadAdjust: &overload.ScaleTimersOverloadActionConfig_ScaleTimer_MinScale{
+				MinScale: &typev3.Percent{Value: minIdleTimeoutScalePercent},
+			},
+		}},
+	})
+	if err != nil {
+		panic(fmt.Sprintf("cannot construct overload action: %s", err))
+	}
+
+	return &overload.OverloadManager{
+		RefreshInterval: durationpb.New(overloadRefreshInterval),
+		ResourceMonitors: []*overload.ResourceMonitor{
+			{
+				Name:       fixedHeapMonitorName,
+				ConfigType: &overload.ResourceMonitor_TypedConfig{TypedConfig: fixedHeap},
+			},
+			{
+				Name:       downstreamConnectionsMonitorName,
+				ConfigType: &overload.ResourceMonitor_TypedConfig{TypedConfig: downstreamConnections},
+			},
+		},
+		Actions: []*overload.OverloadAction{
+			{
+				Name: "envoy.overload_actions.shrink_heap",
+				Triggers: []*overload.Trigger{{
+					Name: fixedHeapMonitorName,
+					TriggerOneof: &overload.Trigger_Threshold{
+						Threshold: &overload.ThresholdTrigger{Value: shrinkHeapThreshold},
+					},
+				}},
+			},
+			{
+				Name: "envoy.overload_actions.reduce_timeouts",
+				Triggers: []*overload.Trigger{{
+					Name: fixedHeapMonitorName,
+					TriggerOneof: &overload.Trigger_Scaled{
+						Scaled: &overload.ScaledTrigger{
+							ScalingThreshold:    reduceTimeoutsScalingThreshold,
+							SaturationThreshold: reduceTimeoutsSaturationThreshold,
+						},
+					},
+				}},
+				TypedConfig: scaleTimers,
+			},
+		},
+	}
+}
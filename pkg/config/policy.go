@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"fmt"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+// LBPolicy encodes the load balancing policy that is applied to the instances of a bundle.
+type LBPolicy string
+
+const (
+	// LBPolicyRoundRobin selects the instances of a bundle in turn.
+	LBPolicyRoundRobin LBPolicy = "round_robin"
+	// LBPolicyLeastRequest selects the instance of a bundle with the fewest active requests.
+	LBPolicyLeastRequest LBPolicy = "least_request"
+	// LBPolicyRandom selects a random instance of a bundle.
+	LBPolicyRandom LBPolicy = "random"
+)
+
+// Retry conditions supported by Envoy for gRPC services, see x-envoy-retry-grpc-on, and for HTTP services, see
+// x-envoy-retry-on.
+var supportedRetryConditions = []string{
+	"cancelled",
+	"deadline-exceeded",
+	"internal",
+	"resource-exhausted",
+	"unavailable",
+	"connect-failure",
+	"refused-stream",
+	"reset",
+	"5xx",
+	"gateway-error",
+	"retriable-4xx",
+}
+
+// RetryPolicy encodes under which conditions and how often a request to a bundle is retried.
+type RetryPolicy struct {
+	RetryOn         []string `json:"retry_on"`
+	NumRetries      uint32   `json:"num_retries"`
+	PerTryTimeoutMs uint32   `json:"per_try_timeout_ms,omitempty"`
+}
+
+// CircuitBreaker encodes the thresholds above which requests to a bundle are rejected immediately.
+type CircuitBreaker struct {
+	MaxConnections     uint32 `json:"max_connections,omitempty"`
+	MaxPendingRequests uint32 `json:"max_pending_requests,omitempty"`
+	MaxRequests        uint32 `json:"max_requests,omitempty"`
+	MaxRetries         uint32 `json:"max_retries,omitempty"`
+}
+
+// HealthCheck encodes how the local instances of a bundle are actively probed. gRPC bundles are probed via the
+// grpc.health.v1 health checking protocol, HTTP bundles via GET requests to the provided path.
+type HealthCheck struct {
+	IntervalMs         uint32 `json:"interval_ms,omitempty"`
+	TimeoutMs          uint32 `json:"timeout_ms,omitempty"`
+	UnhealthyThreshold uint32 `json:"unhealthy_threshold,omitempty"`
+	HealthyThreshold   uint32 `json:"healthy_threshold,omitempty"`
+	Path               string `json:"path,omitempty"`
+	ServiceName        string `json:"service_name,omitempty"`
+}
+
+// OutlierDetection encodes after how many consecutive failures an instance of a bundle is ejected and for how long.
+type OutlierDetection struct {
+	Consecutive5xx            uint32 `json:"consecutive_5xx,omitempty"`
+	ConsecutiveGatewayFailure uint32 `json:"consecutive_gateway_failure,omitempty"`
+	IntervalMs                uint32 `json:"interval_ms,omitempty"`
+	BaseEjectionTimeMs        uint32 `json:"base_ejection_time_ms,omitempty"`
+	MaxEjectionPercent        uint32 `json:"max_ejection_percent,omitempty"`
+}
+
+// TrafficPolicy encodes how the communication middleware treats requests to a bundle. Unset values fall back to the
+// defaults of the communication middleware.
+type TrafficPolicy struct {
+	TimeoutMs        uint32            `json:"timeout_ms,omitempty"`
+	ConnectTimeoutMs uint32            `json:"connect_timeout_ms,omitempty"`
+	LBPolicy         LBPolicy          `json:"lb_policy,omitempty"`
+	Retry            *RetryPolicy      `json:"retry,omitempty"`
+	CircuitBreaker   *CircuitBreaker   `json:"circuit_breaker,omitempty"`
+	HealthCheck      *HealthCheck      `json:"health_check,omitempty"`
+	OutlierDetection *OutlierDetection `json:"outlier_detection,omitempty"`
+	RateLimit        *RateLimit        `json:"rate_limit,omitempty"`
+}
+
+// Validate checks whether the policy only contains supported values.
+func (p *TrafficPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	switch p.LBPolicy {
+	case "", LBPolicyRoundRobin, LBPolicyLeastRequest, LBPolicyRandom:
+	default:
+		return fmt.Errorf("unsupported load balancing policy: %s", p.LBPolicy)
+	}
+
+	if p.Retry != nil {
+		for _, condition := range p.Retry.RetryOn {
+			if !slices.Contains(supportedRetryConditions, condition) {
+				return fmt.Errorf("unsupported retry condition: %s", condition)
+			}
+		}
+	}
+
+	if p.HealthCheck != nil && p.HealthCheck.Path != "" && !strings.HasPrefix(p.HealthCheck.Path, "/") {
+		return fmt.Errorf("health check path must start with '/': %s", p.HealthCheck.Path)
+	}
+
+	if p.OutlierDetection != nil && p.OutlierDetection.MaxEjectionPercent > 100 {
+		return fmt.Errorf("invalid maximum ejection percent: %d", p.OutlierDetection.MaxEjectionPercent)
+	}
+
+	return p.RateLimit.Validate()
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"encoding/json"
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestTrafficPolicyFromJSON(t *testing.T) {
+	expectation := TrafficPolicy{
+		TimeoutMs: 2000,
+		LBPolicy:  LBPolicyLeastRequest,
+		Retry: &RetryPolicy{
+			RetryOn:    []string{"unavailable", "deadline-exceeded"},
+			NumRetries: 3,
+		},
+		CircuitBreaker: &CircuitBreaker{
+			MaxRequests: 128,
+		},
+	}
+
+	fileContent := `{
+	"timeout_ms": 2000,
+	"lb_policy": "least_request",
+	"retry": {
+		"retry_on": ["unavailable", "deadline-exceeded"],
+		"num_retries": 3
+	},
+	"circuit_breaker": {
+		"max_requests": 128
+	}
+}`
+
+	var policy TrafficPolicy
+	assert.NilError(t, json.Unmarshal([]byte(fileContent), &policy))
+
+	assert.DeepEqual(t, policy, expectation)
+	assert.NilError(t, policy.Validate())
+}
+
+func TestTrafficPolicyValidate(t *testing.T) {
+	var policy *TrafficPolicy
+	assert.NilError(t, policy.Validate())
+
+	policy = &TrafficPolicy{LBPolicy: "ring_hash"}
+	assert.ErrorContains(t, policy.Validate(), "load balancing policy")
+
+	policy = &TrafficPolicy{Retry: &RetryPolicy{RetryOn: []string{"not-found"}}}
+	assert.ErrorContains(t, policy.Validate(), "retry condition")
+}
+
+func TestTrafficPolicyValidateHealth(t *testing.T) {
+	policy := &TrafficPolicy{HealthCheck: &HealthCheck{Path: "/healthz"}, OutlierDetection: &OutlierDetection{MaxEjectionPercent: 50}}
+	assert.NilError(t, policy.Validate())
+
+	policy = &TrafficPolicy{HealthCheck: &HealthCheck{Path: "healthz"}}
+	assert.ErrorContains(t, policy.Validate(), "health check path")
+
+	policy = &TrafficPolicy{OutlierDetection: &OutlierDetection{MaxEjectionPercent: 101}}
+	assert.ErrorContains(t, policy.Validate(), "ejection percent")
+}
+
+func TestTrafficPolicyValidateRateLimit(t *testing.T) {
+	policy := &TrafficPolicy{RateLimit: &RateLimit{RequestsPerSecond: 100, PerCaller: map[string]uint32{"infotainment": 10}}}
+	assert.NilError(t, policy.Validate())
+
+	policy = &TrafficPolicy{RateLimit: &RateLimit{PerCaller: map[string]uint32{"infotainment": 0}}}
+	assert.ErrorContains(t, policy.Validate(), "per-caller")
+}
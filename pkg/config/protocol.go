@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Protocol encodes the application protocol a bundle serves.
+type Protocol string
+
+const (
+	// ProtocolGRPC denotes gRPC services, which are routed by their fully-qualified service name.
+	ProtocolGRPC Protocol = "grpc"
+	// ProtocolHTTP denotes HTTP/1.1 services, e.g., REST or WebSocket endpoints.
+	ProtocolHTTP Protocol = "http"
+	// ProtocolHTTP2 denotes HTTP/2 services that are not gRPC services.
+	ProtocolHTTP2 Protocol = "http2"
+)
+
+// Validate checks whether the protocol is supported.
+func (p Protocol) Validate() error {
+	switch p {
+	case "", ProtocolGRPC, ProtocolHTTP, ProtocolHTTP2:
+		return nil
+	default:
+		return fmt.Errorf("unsupported protocol: %s", p)
+	}
+}
+
+// HTTPRoute encodes a path prefix, optionally restricted to requests with exact header values, that a bundle serves.
+// HTTP bundles are always reachable via their bundle ID as host, routes additionally make them reachable via any host.
+type HTTPRoute struct {
+	Prefix  string            `json:"prefix"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ValidateHTTPRoutes checks whether the provided routes are well-formed.
+func ValidateHTTPRoutes(routes []HTTPRoute) error {
+	for _, r := range routes {
+		if !strings.HasPrefix(r.Prefix, "/") {
+			return fmt.Errorf("route prefix must start with '/': %s", r.Prefix)
+		}
+
+		for name := range r.Headers {
+			if name == "" || strings.HasPrefix(name, ":") {
+				return errors.New("route headers must be regular, non-empty header names")
+			}
+		}
+	}
+
+	return nil
+}
+
+// TransportProtocol encodes the transport protocol of a service port.
+type TransportProtocol string
+
+const (
+	// TransportProtocolTCP denotes TCP streams, e.g., MQTT or custom binary protocols.
+	TransportProtocolTCP TransportProtocol = "tcp"
+	// TransportProtocolUDP denotes UDP datagrams, e.g., SOME/IP-style RPC.
+	TransportProtocolUDP TransportProtocol = "udp"
+)
+
+// ServicePort encodes a TCP or UDP port a bundle serves beside its gRPC or HTTP service. Callers reach the service via
+// the port of the service on their own node, which forwards the traffic to the target port of an instance of the bundle.
+type ServicePort struct {
+	Name       string            `json:"name"`
+	Protocol   TransportProtocol `json:"protocol"`
+	Port       uint32            `json:"port"`
+	TargetPort uint32            `json:"target_port"`
+}
+
+// ValidateServicePorts checks whether the provided service ports are well-formed and uniquely named.
+func ValidateServicePorts(ports []ServicePort) error {
+	names := make(map[string]struct{}, len(ports))
+
+	for _, p := range ports {
+		if p.Name == "" {
+			return errors.New("service port name missing")
+		}
+
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("duplicate service port name: %s", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		switch p.Protocol {
+		case TransportProtocolTCP, TransportProtocolUDP:
+		default:
+			return fmt.Errorf("unsupported transport protocol: %s", p.Protocol)
+		}
+
+		if p.Port == 0 || p.Port > 65535 || p.TargetPort == 0 || p.TargetPort > 65535 {
+			return fmt.Errorf("invalid port of service port: %s", p.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestProtocolValidate(t *testing.T) {
+	assert.NilError(t, Protocol("").Validate())
+	assert.NilError(t, ProtocolHTTP.Validate())
+	assert.ErrorContains(t, Protocol("mqtt").Validate(), "unsupported protocol")
+}
+
+func TestValidateHTTPRoutes(t *testing.T) {
+	assert.NilError(t, ValidateHTTPRoutes([]HTTPRoute{{Prefix: "/api", Headers: map[string]string{"x-api-version": "2"}}}))
+	assert.ErrorContains(t, ValidateHTTPRoutes([]HTTPRoute{{Prefix: "api"}}), "must start with '/'")
+	assert.ErrorContains(t, ValidateHTTPRoutes([]HTTPRoute{{Prefix: "/", Headers: map[string]string{":authority": "app"}}}), "header names")
+}
+
+func TestValidateServicePorts(t *testing.T) {
+	assert.NilError(t, ValidateServicePorts([]ServicePort{
+		{Name: "mqtt", Protocol: TransportProtocolTCP, Port: 20000, TargetPort: 1883},
+		{Name: "someip", Protocol: TransportProtocolUDP, Port: 20001, TargetPort: 30490},
+	}))
+
+	assert.ErrorContains(t, ValidateServicePorts([]ServicePort{
+		{Name: "mqtt", Protocol: TransportProtocolTCP, Port: 20000, TargetPort: 1883},
+		{Name: "mqtt", Protocol: TransportProtocolUDP, Port: 20001, TargetPort: 1883},
+	}), "duplicate service port name")
+
+	assert.ErrorContains(t, ValidateServicePorts([]ServicePort{{Name: "mqtt", Protocol: "sctp", Port: 20000, TargetPort: 1883}}),
+		"unsupported transport protocol")
+	assert.ErrorContains(t, ValidateServicePorts([]ServicePort{{Name: "mqtt", Protocol: TransportProtocolTCP, TargetPort: 1883}}),
+		"invalid port")
+}
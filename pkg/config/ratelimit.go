@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RateLimit encodes how many requests per second a bundle accepts as a whole and from individual calling bundles, which
+// are identified by their caller token. Requests without a valid caller token are only subject to the limit of the
+// bundle as a whole. Local limits are enforced by every calling node on its own, i.e., a bundle accepts the configured
+// requests per second from every calling node. Global limits are shared by all nodes via the rate limit service of the
+// control plane.
+type RateLimit struct {
+	RequestsPerSecond uint32            `json:"requests_per_second,omitempty"`
+	PerCaller         map[string]uint32 `json:"per_caller,omitempty"`
+	Global            bool              `json:"global,omitempty"`
+}
+
+// Validate checks whether the rate limit only contains well-formed per-caller limits.
+func (r *RateLimit) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	for caller, rps := range r.PerCaller {
+		if caller == "" || rps == 0 {
+			return errors.New("per-caller rate limits require a caller and a positive limit")
+		}
+	}
+
+	return nil
+}
+
+// RateLimitPolicy declares the rate limit of the target bundle on the level of the mesh. It takes precedence over the
+// rate limit the bundle declares in its metadata.
+type RateLimitPolicy struct {
+	Target string `json:"target"`
+	RateLimit
+}
+
+// RateLimitPoliciesFromJSON converts the JSON representation of a list of rate limit policies and checks whether each
+// bundle is the target of at most one well-formed policy.
+func RateLimitPoliciesFromJSON(j []byte) ([]RateLimitPolicy, error) {
+	var policies []RateLimitPolicy
+	if err := json.Unmarshal(j, &policies); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]struct{}, len(policies))
+
+	for _, p := range policies {
+		if p.Target == "" {
+			return nil, errors.New("target of rate limit policy missing")
+		}
+
+		if _, ok := targets[p.Target]; ok {
+			return nil, fmt.Errorf("duplicate rate limit policy target: %s", p.Target)
+		}
+		targets[p.Target] = struct{}{}
+
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid rate limit policy of %s: %w", p.Target, err)
+		}
+	}
+
+	return policies, nil
+}
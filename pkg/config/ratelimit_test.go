@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package config
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestRateLimitPoliciesFromJSON(t *testing.T) {
+	fileContent := `[
+	{
+		"target": "navigation",
+		"requests_per_second": 100,
+		"per_caller": {"infotainment": 10},
+		"global": true
+	}
+]`
+
+	policies, err := RateLimitPoliciesFromJSON([]byte(fileContent))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, policies, []RateLimitPolicy{{
+		Target: "navigation",
+		RateLimit: RateLimit{
+			RequestsPerSecond: 100,
+			PerCaller:         map[string]uint32{"infotainment": 10},
+			Global:            true,
+		},
+	}})
+}
+
+func TestRateLimitPoliciesFromJSONInvalid(t *testing.T) {
+	_, err := RateLimitPoliciesFromJSON([]byte(`[{"requests_per_second": 10}]`))
+	assert.ErrorContains(t, err, "target")
+
+	_, err = RateLimitPoliciesFromJSON([]byte(`[{"target": "y", "requests_per_second": 10}, {"target": "y"}]`))
+	assert.ErrorContains(t, err, "duplicate")
+
+	_, err = RateLimitPoliciesFromJSON([]byte(`[{"target": "y", "per_caller": {"x": 0}}]`))
+	assert.ErrorContains(t, err, "per-caller")
+}
@@ -5,13 +5,27 @@ package container
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/go-connections/nat"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 )
 
 const (
 	bundleConfigFileName = "info.json"
 	wildcardIP           = "0.0.0.0"
+
+	// Network mode of the Envoy container.
+	envoyNetworkMode = "slirp4netns"
+	// Network mode of bundle containers, which reach the egress listeners of Envoy on the host loopback interface via
+	// the gateway address of their network, 10.0.2.2.
+	bundleNetworkMode = "slirp4netns:allow_host_loopback=true"
+
+	// Environment variable that passes the caller token to a bundle container. The bundle presents it to the egress
+	// listeners of Envoy, which identify the bundle as caller based on it.
+	CallerTokenEnv    = "CARISMA_CALLER_TOKEN"
+	callerTokenLength = 16
 )
 
 // Manager represents the common container manager interface.
@@ -23,10 +37,10 @@ type Manager interface {
 	// StopContainer stops a container identified by its ID.
 	StopContainer(ctx context.Context, id string) error
 	// PullImageAndCreateContainer pulls the requested image from the registry and creates a container with all ports published and the supplied port bindings created.
-	PullImageAndCreateContainer(ctx context.Context, name string, args strslice.StrSlice, portBindings map[nat.Port][]nat.PortBinding, verifyBundleConfig bool) (string, int32,
-		error)
+	PullImageAndCreateContainer(ctx context.Context, name string, args strslice.StrSlice, portBindings map[nat.Port][]nat.PortBinding, verifyBundleConfig bool) (BundleConfig,
+		int32, error)
 	// RemoveImageAndContainer removes the specified image and all associated containers.
-	RemoveImageAndContainer(ctx context.Context, name string, verifyBundleConfig bool) (string, int32, error)
+	RemoveImageAndContainer(ctx context.Context, name string, verifyBundleConfig bool) (BundleConfig, int32, error)
 	// Close closes the connection to the underlying container engine.
 	Close() error
 }
@@ -42,5 +56,29 @@ type Container struct {
 
 // BundleConfig encodes a configuration file that shall be present in every in-car app.
 type BundleConfig struct {
-	BundleID string `json:"bundle_id"`
+	BundleID      string                `json:"bundle_id"`
+	BundleVersion string                `json:"bundle_version,omitempty"`
+	TrafficPolicy *config.TrafficPolicy `json:"traffic_policy,omitempty"`
+	Protocol      config.Protocol       `json:"protocol,omitempty"`
+	Routes        []config.HTTPRoute    `json:"routes,omitempty"`
+	Ports         []config.ServicePort  `json:"ports,omitempty"`
+	Criticality   config.Criticality    `json:"criticality,omitempty"`
+
+	// LocalPorts holds the host ports the container publishes the target ports of the service ports on, keyed by the
+	// name of the service port. It is resolved by the container manager.
+	LocalPorts map[string]int32 `json:"-"`
+
+	// CallerToken holds the secret token the container was started with, see CallerTokenEnv. It is resolved by the
+	// container manager.
+	CallerToken string `json:"-"`
+}
+
+// newCallerToken generates a random caller token, see CallerTokenEnv.
+func newCallerToken() (string, error) {
+	b := make([]byte, callerTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
 }
@@ -83,14 +83,14 @@ func TestDebugContainerManager(t *testing.T) {
 		}
 	}(containerManager)
 
-	bundleID, servicePort, _ := containerManager.PullImageAndCreateContainer(
+	bundleConfig, servicePort, _ := containerManager.PullImageAndCreateContainer(
 		context.Background(),
 		testImageName1,
 		nil,
 		nil,
 		false,
 	)
-	assert.Equal(t, bundleID, fmt.Sprintf(bundleIDFormat, 1))
+	assert.Equal(t, bundleConfig.BundleID, fmt.Sprintf(bundleIDFormat, 1))
 	assert.Equal(t, servicePort, int32(8080))
 
 	containers, _ := containerManager.Containers(context.Background())
@@ -114,20 +114,20 @@ func TestDebugContainerManager(t *testing.T) {
 		assert.Equal(t, containers[idx].Status, statusRunning)
 	}
 
-	bundleID, servicePort, err := containerManager.RemoveImageAndContainer(
+	bundleConfig, servicePort, err := containerManager.RemoveImageAndContainer(
 		context.Background(),
 		testImageName1,
 		false,
 	)
 	assert.NilError(t, err)
 
-	assert.Equal(t, bundleID, fmt.Sprintf(bundleIDFormat, 1))
+	assert.Equal(t, bundleConfig.BundleID, fmt.Sprintf(bundleIDFormat, 1))
 	assert.Equal(t, servicePort, int32(8080))
 
 	containers, _ = containerManager.Containers(context.Background())
 	assert.Equal(t, len(containers), 0)
 
-	bundleID, servicePort, err = containerManager.PullImageAndCreateContainer(
+	bundleConfig, servicePort, err = containerManager.PullImageAndCreateContainer(
 		context.Background(),
 		testImageName2,
 		nil,
@@ -136,17 +136,17 @@ func TestDebugContainerManager(t *testing.T) {
 	)
 	assert.NilError(t, err)
 
-	assert.Equal(t, bundleID, fmt.Sprintf(bundleIDFormat, 2))
+	assert.Equal(t, bundleConfig.BundleID, fmt.Sprintf(bundleIDFormat, 2))
 	assert.Equal(t, servicePort, int32(8081))
 
 	containers, _ = containerManager.Containers(context.Background())
-	bundleID, servicePort, err = containerManager.RemoveImageAndContainer(
+	bundleConfig, servicePort, err = containerManager.RemoveImageAndContainer(
 		context.Background(),
 		containers[0].Image,
 		false,
 	)
 	assert.NilError(t, err)
 
-	assert.Equal(t, bundleID, fmt.Sprintf(bundleIDFormat, 2))
+	assert.Equal(t, bundleConfig.BundleID, fmt.Sprintf(bundleIDFormat, 2))
 	assert.Equal(t, servicePort, int32(8081))
 }
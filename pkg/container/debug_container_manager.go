@@ -148,8 +148,8 @@ func (d *debugContainerManager) StopContainer(_ context.Context, id string) erro
 	return fmt.Errorf("container not found: %v", id)
 }
 
-func (d *debugContainerManager) PullImageAndCreateContainer(_ context.Context, name string, _ strslice.StrSlice, portBindings map[nat.Port][]nat.PortBinding, _ bool) (string,
-	int32, error) {
+func (d *debugContainerManager) PullImageAndCreateContainer(_ context.Context, name string, _ strslice.StrSlice, portBindings map[nat.Port][]nat.PortBinding,
+	_ bool) (BundleConfig, int32, error) {
 	id := generateRandomContainerId()
 	d.container[id] = virtualContainer{
 		d.nextAppIdx,
@@ -167,16 +167,16 @@ func (d *debugContainerManager) PullImageAndCreateContainer(_ context.Context, n
 
 	d.printContainerTable()
 
-	bundleID := fmt.Sprintf("com.mercedes_benz.app_%d", d.nextAppIdx)
+	bundleConfig := BundleConfig{BundleID: fmt.Sprintf(bundleIDFormat, d.nextAppIdx)}
 	servicePort := d.nextServicePort
 
 	d.nextServicePort += 1
 	d.nextAppIdx += 1
 
-	return bundleID, servicePort, nil
+	return bundleConfig, servicePort, nil
 }
 
-func (d *debugContainerManager) RemoveImageAndContainer(_ context.Context, name string, _ bool) (string, int32, error) {
+func (d *debugContainerManager) RemoveImageAndContainer(_ context.Context, name string, _ bool) (BundleConfig, int32, error) {
 	for id, vc := range d.container {
 		if vc.container.Image == name {
 			delete(d.container, id)
@@ -184,18 +184,18 @@ func (d *debugContainerManager) RemoveImageAndContainer(_ context.Context, name
 			_, err := fmt.Fprintf(d.writer, "removing container with ID %s based on image with name %s\n", id, name)
 			logging.LogErr(err)
 
-			bundleID := fmt.Sprintf(bundleIDFormat, vc.appIdx)
+			bundleConfig := BundleConfig{BundleID: fmt.Sprintf(bundleIDFormat, vc.appIdx)}
 			servicePort := servicePortBase - 1 + vc.appIdx
 
 			d.printContainerTable()
 
-			return bundleID, servicePort, nil
+			return bundleConfig, servicePort, nil
 		}
 	}
 
 	d.printContainerTable()
 
-	return "", -1, nil
+	return BundleConfig{}, -1, nil
 }
 
 func (d *debugContainerManager) Close() error {
@@ -16,6 +16,7 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
 	"io"
 	"strconv"
@@ -121,6 +122,57 @@ func (d dockerContainerManager) containerServicePort(ctx context.Context, id str
 	return -1, nil
 }
 
+// containerLocalPorts resolves the host ports the container publishes the target ports of the provided service ports on.
+// Service ports whose target port is not published, e.g., as the image does not expose it, are skipped.
+func (d dockerContainerManager) containerLocalPorts(ctx context.Context, id string, ports []config.ServicePort) (map[string]int32, error) {
+	if len(ports) == 0 {
+		return nil, nil
+	}
+
+	i, err := d.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	localPorts := make(map[string]int32, len(ports))
+	for _, p := range ports {
+		bindings := i.NetworkSettings.Ports[nat.Port(fmt.Sprintf("%d/%s", p.TargetPort, p.Protocol))]
+		if len(bindings) == 0 {
+			logging.DefaultLogger.Error().
+				Str("Container", id).
+				Str("Service-Port", p.Name).
+				Msg("service port not published by container")
+
+			continue
+		}
+
+		localPort, err := strconv.Atoi(bindings[0].HostPort)
+		if err != nil {
+			return nil, err
+		}
+
+		localPorts[p.Name] = int32(localPort)
+	}
+
+	return localPorts, nil
+}
+
+// containerCallerToken resolves the caller token the container was started with, if any.
+func (d dockerContainerManager) containerCallerToken(ctx context.Context, id string) (string, error) {
+	i, err := d.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	for _, env := range i.Config.Env {
+		if token, ok := strings.CutPrefix(env, CallerTokenEnv+"="); ok {
+			return token, nil
+		}
+	}
+
+	return "", nil
+}
+
 // ParseFQIN parses a string into a fully qualified reference.
 func ParseFQIN(name, defaultDomain string) (string, error) {
 	ref, err := reference.ParseNormalizedNamed(name)
@@ -182,12 +234,12 @@ func (d dockerContainerManager) StopContainer(ctx context.Context, id string) er
 }
 
 func (d dockerContainerManager) PullImageAndCreateContainer(ctx context.Context, name string, args strslice.StrSlice, portBindings map[nat.Port][]nat.PortBinding,
-	verifyBundleConfig bool) (string, int32, error) {
+	verifyBundleConfig bool) (BundleConfig, int32, error) {
 
 	// download the image
 	reader, err := d.client.ImagePull(ctx, name, image.PullOptions{})
 	if err != nil {
-		return "", -1, err
+		return BundleConfig{}, -1, err
 	} else {
 		// We need to wait for the download to finish.
 		// The indicator of choice is an EOF error issued by the reader returned by ImagePull.
@@ -200,17 +252,33 @@ func (d dockerContainerManager) PullImageAndCreateContainer(ctx context.Context,
 		_ = reader.Close()
 	}
 
+	// bundles reach the egress listeners of Envoy, which are only published on the host loopback interface
+	networkMode := container.NetworkMode(envoyNetworkMode)
+	var (
+		env         []string
+		callerToken string
+	)
+	if verifyBundleConfig {
+		networkMode = bundleNetworkMode
+
+		// bundles identify themselves as caller with a token that is unknown to all other containers
+		if callerToken, err = newCallerToken(); err != nil {
+			return BundleConfig{}, -1, err
+		}
+		env = []string{CallerTokenEnv + "=" + callerToken}
+	}
+
 	// create container based on image
 	r, err := d.client.ContainerCreate(
 		ctx,
-		&container.Config{Image: name, Cmd: args},
-		&container.HostConfig{NetworkMode: "slirp4netns", PublishAllPorts: true, PortBindings: portBindings},
+		&container.Config{Image: name, Cmd: args, Env: env},
+		&container.HostConfig{NetworkMode: networkMode, PublishAllPorts: true, PortBindings: portBindings},
 		nil,
 		nil,
 		"",
 	)
 	if err != nil {
-		return "", -1, err
+		return BundleConfig{}, -1, err
 	}
 
 	// start container
@@ -219,55 +287,70 @@ func (d dockerContainerManager) PullImageAndCreateContainer(ctx context.Context,
 		r.ID,
 		container.StartOptions{},
 	); err != nil {
-		return "", -1, err
+		return BundleConfig{}, -1, err
 	}
 
 	// success but also retrieve bundleID and servicePort
 	if verifyBundleConfig {
 		bundleConfig, err := d.bundleConfiguration(ctx, r.ID)
 		if err != nil {
-			return "", -1, err
+			return BundleConfig{}, -1, err
 		}
 
 		servicePort, err := d.containerServicePort(ctx, r.ID)
 		if err != nil {
-			return "", -1, err
+			return BundleConfig{}, -1, err
 		}
 
-		return bundleConfig.BundleID, servicePort, nil
+		bundleConfig.LocalPorts, err = d.containerLocalPorts(ctx, r.ID, bundleConfig.Ports)
+		if err != nil {
+			return BundleConfig{}, -1, err
+		}
+
+		bundleConfig.CallerToken = callerToken
+
+		return bundleConfig, servicePort, nil
 	}
 
-	return "", -1, nil
+	return BundleConfig{}, -1, nil
 }
 
-func (d dockerContainerManager) RemoveImageAndContainer(ctx context.Context, imageName string, verifyBundleConfig bool) (string, int32, error) {
+func (d dockerContainerManager) RemoveImageAndContainer(ctx context.Context, imageName string, verifyBundleConfig bool) (BundleConfig, int32, error) {
 	containerList, err := d.client.ContainerList(ctx, container.ListOptions{
 		Filters: filters.NewArgs(filters.KeyValuePair{Key: "ancestor", Value: imageName}),
 	})
 	if err != nil {
-		return "", -1, err
+		return BundleConfig{}, -1, err
 	}
 
 	if len(containerList) == 0 {
-		return "", -1, errors.New("can not not find container(s) running the supplied image")
+		return BundleConfig{}, -1, errors.New("can not not find container(s) running the supplied image")
 	}
 
 	var (
-		bundleID          = ""
-		servicePort int32 = -1
+		bundleConfig       = BundleConfig{}
+		servicePort  int32 = -1
 	)
 
 	if verifyBundleConfig {
-		bundleConfig, err := d.bundleConfiguration(ctx, containerList[0].ID)
+		bundleConfig, err = d.bundleConfiguration(ctx, containerList[0].ID)
 		if err != nil {
-			return "", -1, err
+			return BundleConfig{}, -1, err
 		}
 
-		bundleID = bundleConfig.BundleID
-
 		servicePort, err = d.containerServicePort(ctx, containerList[0].ID)
 		if err != nil {
-			return "", -1, err
+			return BundleConfig{}, -1, err
+		}
+
+		bundleConfig.LocalPorts, err = d.containerLocalPorts(ctx, containerList[0].ID, bundleConfig.Ports)
+		if err != nil {
+			return BundleConfig{}, -1, err
+		}
+
+		bundleConfig.CallerToken, err = d.containerCallerToken(ctx, containerList[0].ID)
+		if err != nil {
+			return BundleConfig{}, -1, err
 		}
 	}
 
@@ -276,7 +359,7 @@ func (d dockerContainerManager) RemoveImageAndContainer(ctx context.Context, ima
 		containerList[0].ID,
 		container.RemoveOptions{Force: true},
 	); err != nil {
-		return "", -1, err
+		return BundleConfig{}, -1, err
 	}
 
 	if _, err := d.client.ImageRemove(
@@ -284,10 +367,10 @@ func (d dockerContainerManager) RemoveImageAndContainer(ctx context.Context, ima
 		containerList[0].ImageID,
 		image.RemoveOptions{Force: true},
 	); err != nil {
-		return "", -1, err
+		return BundleConfig{}, -1, err
 	}
 
-	return bundleID, servicePort, nil
+	return bundleConfig, servicePort, nil
 }
 
 func (d dockerContainerManager) Close() error {
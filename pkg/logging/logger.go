@@ -72,3 +72,8 @@ func LogErr(err error) {
 		DefaultLogger.Error().Err(err).Msg("")
 	}
 }
+
+// SecurityEvent starts a warning that is marked as security event, e.g., a rejected attempt to impersonate a node.
+func SecurityEvent() *zerolog.Event {
+	return DefaultLogger.Warn().Str("Event", "security")
+}
@@ -0,0 +1,408 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+// Package metrics implements counters, gauges and histograms that are exported in the Prometheus text format. Metrics are
+// a separate concern from logging, i.e., they never replace the logs written via the logging package.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Path the metrics are served at.
+	Path = "/metrics"
+
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// DefaultBuckets are the upper bounds of the histogram buckets suitable for durations in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// metric is implemented by all metric types of the registry.
+type metric interface {
+	write(w io.Writer) error
+}
+
+// Registry holds the metrics exported by a process.
+type Registry struct {
+	mu      sync.Mutex // protects metrics
+	metrics map[string]metric
+}
+
+// NewRegistry creates a new, empty instance of Registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]metric)}
+}
+
+// DefaultRegistry is the registry the metrics created by the package-level constructors belong to.
+var DefaultRegistry = NewRegistry()
+
+// register adds the provided metric to the registry. Registering a metric name twice is a programming error.
+func (r *Registry) register(name string, m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.metrics[name]; ok {
+		panic(fmt.Sprintf("metric %s registered twice", name))
+	}
+
+	r.metrics[name] = m
+}
+
+// Write writes all metrics of the registry in the Prometheus text format, ordered by their name.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	names := maps.Keys(r.metrics)
+	metrics := maps.Clone(r.metrics)
+	r.mu.Unlock()
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := metrics[name].write(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handler returns the handler that serves the metrics of the registry.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+
+		logging.LogErr(r.Write(w))
+	})
+}
+
+// Handler returns the handler that serves the metrics of the default registry.
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+// Serve serves the metrics of the default registry at the provided address until the provided context is done.
+func Serve(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+Path, Handler())
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		logging.LogErr(srv.Shutdown(shutdownCtx))
+	}()
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		logging.LogErr(err)
+	}
+}
+
+// desc describes a metric and the names of its labels.
+type desc struct {
+	name       string
+	help       string
+	typ        string
+	labelNames []string
+}
+
+func (d desc) writeHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, strings.ReplaceAll(d.help, "\n", " "), d.name, d.typ)
+
+	return err
+}
+
+// key encodes the provided label values as key of the series they identify. Passing the wrong number of label values is
+// a programming error.
+func (d desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", d.name, len(d.labelNames), len(labelValues)))
+	}
+
+	return strings.Join(labelValues, "\xff")
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats the provided label pairs, optionally followed by an additional pair, e.g., the bucket of a histogram.
+func labels(names, values []string, extra ...string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, labelValueEscaper.Replace(values[i])))
+	}
+
+	if len(extra) == 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[0], extra[1]))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type sample struct {
+	labelValues []string
+	value       float64
+}
+
+// value implements the series of counters and gauges.
+type value struct {
+	desc
+
+	mu      sync.Mutex // protects samples
+	samples map[string]*sample
+}
+
+func (v *value) add(delta float64, labelValues []string) {
+	key := v.key(labelValues)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	s, ok := v.samples[key]
+	if !ok {
+		s = &sample{labelValues: slices.Clone(labelValues)}
+		v.samples[key] = s
+	}
+
+	s.value += delta
+}
+
+func (v *value) write(w io.Writer) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if err := v.writeHeader(w); err != nil {
+		return err
+	}
+
+	// a metric without labels is always present
+	if len(v.labelNames) == 0 && len(v.samples) == 0 {
+		_, err := fmt.Fprintf(w, "%s 0\n", v.name)
+
+		return err
+	}
+
+	keys := maps.Keys(v.samples)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		s := v.samples[key]
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", v.name, labels(v.labelNames, s.labelValues), formatFloat(s.value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Counter is a metric whose value only increases, e.g., the number of failed operations.
+type Counter struct {
+	value
+}
+
+// NewCounter creates a counter with the provided labels and registers it with the registry.
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	c := &Counter{value{desc: desc{name: name, help: help, typ: "counter", labelNames: labelNames}, samples: make(map[string]*sample)}}
+	r.register(name, c)
+
+	return c
+}
+
+// NewCounter creates a counter with the provided labels and registers it with the default registry.
+func NewCounter(name, help string, labelNames ...string) *Counter {
+	return DefaultRegistry.NewCounter(name, help, labelNames...)
+}
+
+// Inc increments the counter identified by the provided label values by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.add(1, labelValues)
+}
+
+// Gauge is a metric whose value may increase and decrease, e.g., the number of open connections.
+type Gauge struct {
+	value
+}
+
+// NewGauge creates a gauge with the provided labels and registers it with the registry.
+func (r *Registry) NewGauge(name, help string, labelNames ...string) *Gauge {
+	g := &Gauge{value{desc: desc{name: name, help: help, typ: "gauge", labelNames: labelNames}, samples: make(map[string]*sample)}}
+	r.register(name, g)
+
+	return g
+}
+
+// NewGauge creates a gauge with the provided labels and registers it with the default registry.
+func NewGauge(name, help string, labelNames ...string) *Gauge {
+	return DefaultRegistry.NewGauge(name, help, labelNames...)
+}
+
+// Set sets the gauge identified by the provided label values.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	key := g.key(labelValues)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.samples[key] = &sample{labelValues: slices.Clone(labelValues), value: v}
+}
+
+// Inc increments the gauge identified by the provided label values by one.
+func (g *Gauge) Inc(labelValues ...string) {
+	g.add(1, labelValues)
+}
+
+// Dec decrements the gauge identified by the provided label values by one.
+func (g *Gauge) Dec(labelValues ...string) {
+	g.add(-1, labelValues)
+}
+
+// Reset drops all series of the gauge, e.g., before the gauges of the nodes that are still registered are set again.
+func (g *Gauge) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.samples = make(map[string]*sample)
+}
+
+type observations struct {
+	labelValues []string
+	counts      []uint64 // per bucket, not cumulative
+	count       uint64
+	sum         float64
+}
+
+// Histogram is a metric that counts observations in buckets, e.g., the duration of an operation.
+type Histogram struct {
+	desc
+	buckets []float64
+
+	mu           sync.Mutex // protects observations
+	observations map[string]*observations
+}
+
+// NewHistogram creates a histogram with the provided bucket upper bounds and labels and registers it with the registry.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	h := &Histogram{
+		desc:         desc{name: name, help: help, typ: "histogram", labelNames: labelNames},
+		buckets:      buckets,
+		observations: make(map[string]*observations),
+	}
+	r.register(name, h)
+
+	return h
+}
+
+// NewHistogram creates a histogram with the provided bucket upper bounds and labels and registers it with the default
+// registry.
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	return DefaultRegistry.NewHistogram(name, help, buckets, labelNames...)
+}
+
+// Observe adds an observation to the histogram identified by the provided label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	o, ok := h.observations[key]
+	if !ok {
+		o = &observations{labelValues: slices.Clone(labelValues), counts: make([]uint64, len(h.buckets))}
+		h.observations[key] = o
+	}
+
+	if i, _ := slices.BinarySearch(h.buckets, v); i < len(h.buckets) {
+		o.counts[i]++
+	}
+
+	o.count++
+	o.sum += v
+}
+
+// ObserveDuration adds the time passed since the provided start in seconds to the histogram identified by the provided
+// label values.
+func (h *Histogram) ObserveDuration(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.writeHeader(w); err != nil {
+		return err
+	}
+
+	keys := maps.Keys(h.observations)
+	slices.Sort(keys)
+
+	if len(h.labelNames) == 0 && len(keys) == 0 {
+		h.observations[""] = &observations{counts: make([]uint64, len(h.buckets))}
+		keys = []string{""}
+	}
+
+	for _, key := range keys {
+		o := h.observations[key]
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += o.counts[i]
+
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labels(h.labelNames, o.labelValues, "le", formatFloat(bound)), cumulative); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, labels(h.labelNames, o.labelValues, "le", "+Inf"), o.count,
+			h.name, labels(h.labelNames, o.labelValues), formatFloat(o.sum),
+			h.name, labels(h.labelNames, o.labelValues), o.count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package metrics
+
+import (
+	"gotest.tools/v3/assert"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+
+	nacks := r.NewCounter("test_nacks_total", "The number of NACKs.", "node")
+	nacks.Inc("b")
+	nacks.Inc("a")
+	nacks.Inc("a")
+
+	streams := r.NewGauge("test_streams", "The number of streams.")
+	streams.Inc()
+	streams.Inc()
+	streams.Dec()
+
+	latency := r.NewHistogram("test_latency_seconds", "The latency.", []float64{1, 0.1})
+	latency.Observe(0.05)
+	latency.Observe(0.5)
+	latency.Observe(2)
+
+	var b strings.Builder
+	assert.NilError(t, r.Write(&b))
+	assert.Equal(t, b.String(), `# HELP test_latency_seconds The latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{le="0.1"} 1
+test_latency_seconds_bucket{le="1"} 2
+test_latency_seconds_bucket{le="+Inf"} 3
+test_latency_seconds_sum 2.55
+test_latency_seconds_count 3
+# HELP test_nacks_total The number of NACKs.
+# TYPE test_nacks_total counter
+test_nacks_total{node="a"} 2
+test_nacks_total{node="b"} 1
+# HELP test_streams The number of streams.
+# TYPE test_streams gauge
+test_streams 1
+`)
+}
+
+func TestGaugeReset(t *testing.T) {
+	r := NewRegistry()
+
+	services := r.NewGauge("test_services", "The number of services.", "node")
+	services.Set(3, `node "a"`)
+	services.Reset()
+	services.Set(1, "b")
+
+	var b strings.Builder
+	assert.NilError(t, r.Write(&b))
+	assert.Assert(t, !strings.Contains(b.String(), `node \"a\"`))
+	assert.Assert(t, strings.Contains(b.String(), `test_services{node="b"} 1`))
+}
@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// Validity of the root certificate of the certificate authority.
+	caValidity = 10 * 365 * 24 * time.Hour
+
+	// Tolerated clock skew between the nodes of the vehicle.
+	clockSkew = 5 * time.Minute
+
+	caCommonName = "CARISMA Root CA"
+	organization = "CARISMA"
+
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypePrivateKey  = "EC PRIVATE KEY"
+)
+
+// CA implements a minimal certificate authority that issues certificates for the nodes of the mesh.
+type CA struct {
+	mu      sync.Mutex // serializes the issuance of certificates
+	cert    *x509.Certificate
+	certPEM []byte
+	key     *ecdsa.PrivateKey
+}
+
+// LoadOrCreateCA loads the certificate authority persisted at the provided file paths. If no certificate authority is
+// present yet, a new one is created and persisted.
+func LoadOrCreateCA(certFilePath, keyFilePath string) (*CA, error) {
+	certPEM, errCert := os.ReadFile(certFilePath)
+	keyPEM, errKey := os.ReadFile(keyFilePath)
+
+	if os.IsNotExist(errCert) && os.IsNotExist(errKey) {
+		ca, err := NewCA()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ca.write(certFilePath, keyFilePath); err != nil {
+			return nil, err
+		}
+
+		return ca, nil
+	} else if errCert != nil {
+		return nil, errCert
+	} else if errKey != nil {
+		return nil, errKey
+	}
+
+	return parseCA(certPEM, keyPEM)
+}
+
+// LoadCA loads the certificate authority persisted at the provided file paths. Unlike LoadOrCreateCA, it fails if no
+// certificate authority is present, e.g., as control planes in high availability mode have to share the same one.
+func LoadCA(certFilePath, keyFilePath string) (*CA, error) {
+	certPEM, err := os.ReadFile(certFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := os.ReadFile(keyFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCA(certPEM, keyPEM)
+}
+
+func parseCA(certPEM, keyPEM []byte) (*CA, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil || keyBlock.Type != pemTypePrivateKey {
+		return nil, errors.New("invalid private key of certificate authority")
+	}
+
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{cert: cert, certPEM: certPEM, key: key}, nil
+}
+
+// NewCA creates a new certificate authority with a self-signed root certificate.
+func NewCA() (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   caCommonName,
+			Organization: []string{organization},
+		},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		cert:    cert,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: der}),
+		key:     key,
+	}, nil
+}
+
+// CertificatePEM returns the PEM-encoded root certificate of the certificate authority.
+func (c *CA) CertificatePEM() []byte {
+	return c.certPEM
+}
+
+// CertPool returns a certificate pool that only contains the root certificate of the certificate authority.
+func (c *CA) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.cert)
+
+	return pool
+}
+
+// Issue issues a certificate for the provided identity that is valid for the provided duration. Hosts that are IP
+// addresses are encoded as IP SANs, all others as DNS SANs. The certificate can be used for both, server and client
+// authentication.
+func (c *CA) Issue(commonName string, hosts []string, uris []*url.URL, ttl time.Duration) (*Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	var dnsNames []string
+	var ipAddresses []net.IP
+	for _, host := range hosts {
+		if host == "" || slices.Contains(dnsNames, host) {
+			continue
+		}
+
+		if ip := net.ParseIP(host); ip != nil {
+			ipAddresses = append(ipAddresses, ip)
+		} else {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{organization},
+		},
+		DNSNames:    dnsNames,
+		IPAddresses: ipAddresses,
+		URIs:        uris,
+		NotBefore:   now.Add(-clockSkew),
+		NotAfter:    now.Add(ttl),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, c.cert, &key.PublicKey, c.key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Certificate{
+		CertificatePEM: pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: der}),
+		PrivateKeyPEM:  pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: keyDER}),
+		NotBefore:      template.NotBefore,
+		NotAfter:       template.NotAfter,
+	}, nil
+}
+
+func (c *CA) write(certFilePath, keyFilePath string) error {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(certFilePath), 0755); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyFilePath), 0700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(certFilePath, c.certPEM, 0644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(keyFilePath, pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: keyDER}), 0600)
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != pemTypeCertificate {
+		return nil, errors.New("invalid PEM-encoded certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
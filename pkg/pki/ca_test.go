@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package pki
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"gotest.tools/v3/assert"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIssue(t *testing.T) {
+	ca, err := NewCA()
+	assert.NilError(t, err)
+
+	c, err := ca.Issue("node-hpc-1", nil, []*url.URL{NodeIdentity("node-hpc-1")}, time.Hour)
+	assert.NilError(t, err)
+
+	cert, err := parseCertificate(c.CertificatePEM)
+	assert.NilError(t, err)
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     ca.CertPool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(cert.URIs), 1)
+	assert.Equal(t, cert.URIs[0].String(), "spiffe://carisma/node/node-hpc-1")
+
+	nodeID, ok := NodeIDFromIdentity(cert.URIs[0])
+	assert.Assert(t, ok)
+	assert.Equal(t, nodeID, "node-hpc-1")
+
+	_, err = c.TLSCertificate()
+	assert.NilError(t, err)
+}
+
+func TestNeedsRotation(t *testing.T) {
+	now := time.Now()
+	c := &Certificate{NotBefore: now, NotAfter: now.Add(3 * time.Hour)}
+
+	assert.Assert(t, !c.NeedsRotation(now.Add(time.Hour)))
+	assert.Assert(t, c.NeedsRotation(now.Add(2*time.Hour+time.Minute)))
+}
+
+func TestLoadOrCreateCA(t *testing.T) {
+	certFilePath := filepath.Join(t.TempDir(), "ca.pem")
+	keyFilePath := filepath.Join(t.TempDir(), "private", "ca-key.pem")
+
+	ca, err := LoadOrCreateCA(certFilePath, keyFilePath)
+	assert.NilError(t, err)
+
+	restoredCA, err := LoadOrCreateCA(certFilePath, keyFilePath)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, restoredCA.CertificatePEM(), ca.CertificatePEM())
+
+	// certificates issued by the restored CA are trusted by holders of the original root certificate
+	c, err := restoredCA.Issue("node-hpc-1", nil, nil, time.Hour)
+	assert.NilError(t, err)
+
+	cert, err := parseCertificate(c.CertificatePEM)
+	assert.NilError(t, err)
+
+	_, err = cert.Verify(x509.VerifyOptions{Roots: ca.CertPool()})
+	assert.NilError(t, err)
+}
+
+func TestTLSConfig(t *testing.T) {
+	ca, err := NewCA()
+	assert.NilError(t, err)
+
+	dir := t.TempDir()
+	caCertFilePath := filepath.Join(dir, "ca.pem")
+	assert.NilError(t, os.WriteFile(caCertFilePath, ca.CertificatePEM(), 0644))
+
+	serverConfig, err := ca.ServerTLSConfig("carisma-control-plane", []string{"carisma-central", "127.0.0.1"}, time.Hour)
+	assert.NilError(t, err)
+
+	nodeCert, err := ca.Issue("node-hpc-1", nil, []*url.URL{NodeIdentity("node-hpc-1")}, time.Hour)
+	assert.NilError(t, err)
+	assert.NilError(t, WriteCertificate(nodeCert, filepath.Join(dir, "node.pem"), filepath.Join(dir, "node-key.pem")))
+
+	clientConfig, err := ClientTLSConfig(caCertFilePath, "carisma-central", filepath.Join(dir, "node.pem"), filepath.Join(dir, "node-key.pem"))
+	assert.NilError(t, err)
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	server := tls.Server(serverConn, serverConfig)
+	errServer := make(chan error, 1)
+	go func() {
+		errServer <- server.Handshake()
+	}()
+
+	assert.NilError(t, tls.Client(clientConn, clientConfig).Handshake())
+	assert.NilError(t, <-errServer)
+
+	peerCerts := server.ConnectionState().PeerCertificates
+	assert.Equal(t, len(peerCerts), 1)
+	assert.Equal(t, peerCerts[0].URIs[0].String(), "spiffe://carisma/node/node-hpc-1")
+}
+
+func TestTakeoverWithSharedCA(t *testing.T) {
+	primaryDir, standbyDir, nodeDir := t.TempDir(), t.TempDir(), t.TempDir()
+
+	_, err := LoadCA(filepath.Join(standbyDir, "ca.pem"), filepath.Join(standbyDir, "ca-key.pem"))
+	assert.Assert(t, os.IsNotExist(err))
+
+	primaryCA, err := LoadOrCreateCA(filepath.Join(primaryDir, "ca.pem"), filepath.Join(primaryDir, "ca-key.pem"))
+	assert.NilError(t, err)
+
+	// the certificate authority of the primary control plane is provisioned on the standby control plane and the node
+	for _, name := range []string{"ca.pem", "ca-key.pem"} {
+		b, err := os.ReadFile(filepath.Join(primaryDir, name))
+		assert.NilError(t, err)
+		assert.NilError(t, os.WriteFile(filepath.Join(standbyDir, name), b, 0600))
+	}
+	assert.NilError(t, os.WriteFile(filepath.Join(nodeDir, "ca.pem"), primaryCA.CertificatePEM(), 0644))
+
+	standbyCA, err := LoadCA(filepath.Join(standbyDir, "ca.pem"), filepath.Join(standbyDir, "ca-key.pem"))
+	assert.NilError(t, err)
+
+	nodeCert, err := primaryCA.Issue("node-hpc-1", nil, []*url.URL{NodeIdentity("node-hpc-1")}, time.Hour)
+	assert.NilError(t, err)
+	assert.NilError(t, WriteCertificate(nodeCert, filepath.Join(nodeDir, "node.pem"), filepath.Join(nodeDir, "node-key.pem")))
+
+	// after the takeover, the node trusts the standby control plane and authenticates itself with its certificate
+	serverConfig, err := standbyCA.ServerTLSConfig("carisma-control-plane", []string{"carisma-standby"}, time.Hour)
+	assert.NilError(t, err)
+
+	clientConfig, err := ClientTLSConfig(filepath.Join(nodeDir, "ca.pem"), "carisma-standby",
+		filepath.Join(nodeDir, "node.pem"), filepath.Join(nodeDir, "node-key.pem"))
+	assert.NilError(t, err)
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	server := tls.Server(serverConn, serverConfig)
+	errServer := make(chan error, 1)
+	go func() {
+		errServer <- server.Handshake()
+	}()
+
+	assert.NilError(t, tls.Client(clientConn, clientConfig).Handshake())
+	assert.NilError(t, <-errServer)
+
+	peerCerts := server.ConnectionState().PeerCertificates
+	assert.Equal(t, len(peerCerts), 1)
+
+	_, err = peerCerts[0].Verify(x509.VerifyOptions{
+		Roots:     standbyCA.CertPool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	assert.NilError(t, err)
+}
+
+func TestPeerTLSConfig(t *testing.T) {
+	ca, err := NewCA()
+	assert.NilError(t, err)
+
+	serverConfig, err := ca.ServerTLSConfig("carisma-control-plane", []string{"carisma-central"}, time.Hour)
+	assert.NilError(t, err)
+
+	peerConfig, err := ca.PeerTLSConfig("carisma-standby", "carisma-central", time.Hour)
+	assert.NilError(t, err)
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	server := tls.Server(serverConn, serverConfig)
+	errServer := make(chan error, 1)
+	go func() {
+		errServer <- server.Handshake()
+	}()
+
+	assert.NilError(t, tls.Client(clientConn, peerConfig).Handshake())
+	assert.NilError(t, <-errServer)
+
+	peerCerts := server.ConnectionState().PeerCertificates
+	assert.Equal(t, len(peerCerts), 1)
+	assert.Equal(t, peerCerts[0].Subject.CommonName, "carisma-standby")
+}
+
+func TestWriteCertificateCreatesDirectories(t *testing.T) {
+	ca, err := NewCA()
+	assert.NilError(t, err)
+
+	nodeCert, err := ca.Issue("node-hpc-1", nil, []*url.URL{NodeIdentity("node-hpc-1")}, time.Hour)
+	assert.NilError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "conf")
+	assert.NilError(t, WriteCertificate(nodeCert, filepath.Join(dir, "node.pem"), filepath.Join(dir, "keys", "node-key.pem")))
+
+	info, err := os.Stat(filepath.Join(dir, "keys", "node-key.pem"))
+	assert.NilError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0600))
+
+	_, err = ClientTLSConfig(filepath.Join(dir, "ca.pem"), "carisma-central", filepath.Join(dir, "node.pem"), filepath.Join(dir, "keys", "node-key.pem"))
+	assert.ErrorContains(t, err, "copied from the central node")
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package pki
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Trust domain of the identities of the nodes.
+	trustDomain = "carisma"
+
+	nodeIdentityPathPrefix = "/node/"
+)
+
+// Certificate encodes a PEM-encoded certificate together with its private key.
+type Certificate struct {
+	CertificatePEM []byte
+	PrivateKeyPEM  []byte
+	NotBefore      time.Time
+	NotAfter       time.Time
+}
+
+// NeedsRotation checks whether two thirds of the lifetime of the certificate have passed at the provided point in time.
+func (c *Certificate) NeedsRotation(now time.Time) bool {
+	lifetime := c.NotAfter.Sub(c.NotBefore)
+
+	return now.After(c.NotBefore.Add(lifetime * 2 / 3))
+}
+
+// TLSCertificate converts the certificate into a certificate usable by crypto/tls.
+func (c *Certificate) TLSCertificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(c.CertificatePEM, c.PrivateKeyPEM)
+}
+
+// NodeIdentity returns the SPIFFE-like URI that identifies the node with the provided ID within certificates.
+func NodeIdentity(nodeID string) *url.URL {
+	return &url.URL{
+		Scheme: "spiffe",
+		Host:   trustDomain,
+		Path:   nodeIdentityPathPrefix + nodeID,
+	}
+}
+
+// NodeIdentityPrefix returns the prefix that is shared by the identities of all nodes.
+func NodeIdentityPrefix() string {
+	return fmt.Sprintf("spiffe://%s%s", trustDomain, nodeIdentityPathPrefix)
+}
+
+// NodeIDFromIdentity extracts the node ID from the provided identity.
+func NodeIDFromIdentity(identity *url.URL) (string, bool) {
+	if identity.Scheme != "spiffe" || identity.Host != trustDomain || !strings.HasPrefix(identity.Path, nodeIdentityPathPrefix) {
+		return "", false
+	}
+
+	nodeID := strings.TrimPrefix(identity.Path, nodeIdentityPathPrefix)
+
+	return nodeID, nodeID != ""
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package pki
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ServerTLSConfig creates a TLS configuration for a server that presents a certificate issued by the certificate
+// authority for the provided hosts. The certificate is reissued once it needs to be rotated. Clients may present a
+// certificate issued by the certificate authority, which is verified if present.
+func (c *CA) ServerTLSConfig(commonName string, hosts []string, ttl time.Duration) (*tls.Config, error) {
+	var mu sync.Mutex
+	cert, err := c.Issue(commonName, hosts, nil, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCert, err := cert.TLSCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  c.CertPool(),
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if cert.NeedsRotation(time.Now()) {
+				rotatedCert, err := c.Issue(commonName, hosts, nil, ttl)
+				if err != nil {
+					return nil, err
+				}
+
+				rotatedTLSCert, err := rotatedCert.TLSCertificate()
+				if err != nil {
+					return nil, err
+				}
+
+				cert, tlsCert = rotatedCert, rotatedTLSCert
+			}
+
+			return &tlsCert, nil
+		},
+	}, nil
+}
+
+// ClientTLSConfig creates a TLS configuration for a client that trusts the root certificate persisted at the provided
+// file path. If a certificate and private key are present at the provided file paths when connecting, the client
+// presents them to the server.
+func ClientTLSConfig(caCertFilePath, serverName, certFilePath, keyFilePath string) (*tls.Config, error) {
+	caCertPEM, err := os.ReadFile(caCertFilePath)
+	if err != nil {
+		// the root certificate is not distributed via the control channel, as it is needed to trust the control plane
+		return nil, fmt.Errorf("root certificate of the control plane has to be copied from the central node: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCertPEM) {
+		return nil, errors.New("invalid root certificate of certificate authority")
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			tlsCert, err := tls.LoadX509KeyPair(certFilePath, keyFilePath)
+			if err != nil || (tlsCert.Leaf != nil && time.Now().After(tlsCert.Leaf.NotAfter)) {
+				// the client is admitted by other means, e.g., an admission token
+				return &tls.Certificate{}, nil
+			}
+
+			return &tlsCert, nil
+		},
+	}, nil
+}
+
+// PeerTLSConfig creates a TLS configuration for a client that trusts the certificate authority and presents a
+// certificate issued by it, e.g., a control plane that connects to its peer. The certificate is reissued once it needs
+// to be rotated.
+func (c *CA) PeerTLSConfig(commonName, serverName string, ttl time.Duration) (*tls.Config, error) {
+	var mu sync.Mutex
+	cert, err := c.Issue(commonName, nil, nil, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCert, err := cert.TLSCertificate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    c.CertPool(),
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if cert.NeedsRotation(time.Now()) {
+				rotatedCert, err := c.Issue(commonName, nil, nil, ttl)
+				if err != nil {
+					return nil, err
+				}
+
+				rotatedTLSCert, err := rotatedCert.TLSCertificate()
+				if err != nil {
+					return nil, err
+				}
+
+				cert, tlsCert = rotatedCert, rotatedTLSCert
+			}
+
+			return &tlsCert, nil
+		},
+	}, nil
+}
+
+// WriteCertificate persists the provided certificate and its private key at the provided file paths.
+func WriteCertificate(cert *Certificate, certFilePath, keyFilePath string) error {
+	if err := os.MkdirAll(filepath.Dir(certFilePath), 0755); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyFilePath), 0700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(keyFilePath, cert.PrivateKeyPEM, 0600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(certFilePath, cert.CertificatePEM, 0644)
+}
@@ -14,6 +14,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -22,8 +23,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	NodeRegistryService_Register_FullMethodName    = "/carisma.node.v1.NodeRegistryService/Register"
-	NodeRegistryService_OpenChannel_FullMethodName = "/carisma.node.v1.NodeRegistryService/OpenChannel"
+	NodeRegistryService_Register_FullMethodName         = "/carisma.node.v1.NodeRegistryService/Register"
+	NodeRegistryService_Deregister_FullMethodName       = "/carisma.node.v1.NodeRegistryService/Deregister"
+	NodeRegistryService_OpenChannel_FullMethodName      = "/carisma.node.v1.NodeRegistryService/OpenChannel"
+	NodeRegistryService_ListNodeLiveness_FullMethodName = "/carisma.node.v1.NodeRegistryService/ListNodeLiveness"
 )
 
 // NodeRegistryServiceClient is the client API for NodeRegistryService service.
@@ -31,7 +34,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NodeRegistryServiceClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	OpenChannel(ctx context.Context, opts ...grpc.CallOption) (NodeRegistryService_OpenChannelClient, error)
+	ListNodeLiveness(ctx context.Context, in *ListNodeLivenessRequest, opts ...grpc.CallOption) (*ListNodeLivenessResponse, error)
 }
 
 type nodeRegistryServiceClient struct {
@@ -51,6 +56,15 @@ func (c *nodeRegistryServiceClient) Register(ctx context.Context, in *RegisterRe
 	return out, nil
 }
 
+func (c *nodeRegistryServiceClient) Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NodeRegistryService_Deregister_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeRegistryServiceClient) OpenChannel(ctx context.Context, opts ...grpc.CallOption) (NodeRegistryService_OpenChannelClient, error) {
 	stream, err := c.cc.NewStream(ctx, &NodeRegistryService_ServiceDesc.Streams[0], NodeRegistryService_OpenChannel_FullMethodName, opts...)
 	if err != nil {
@@ -82,12 +96,23 @@ func (x *nodeRegistryServiceOpenChannelClient) Recv() (*DeploymentConfiguration,
 	return m, nil
 }
 
+func (c *nodeRegistryServiceClient) ListNodeLiveness(ctx context.Context, in *ListNodeLivenessRequest, opts ...grpc.CallOption) (*ListNodeLivenessResponse, error) {
+	out := new(ListNodeLivenessResponse)
+	err := c.cc.Invoke(ctx, NodeRegistryService_ListNodeLiveness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeRegistryServiceServer is the server API for NodeRegistryService service.
 // All implementations must embed UnimplementedNodeRegistryServiceServer
 // for forward compatibility
 type NodeRegistryServiceServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Deregister(context.Context, *DeregisterRequest) (*emptypb.Empty, error)
 	OpenChannel(NodeRegistryService_OpenChannelServer) error
+	ListNodeLiveness(context.Context, *ListNodeLivenessRequest) (*ListNodeLivenessResponse, error)
 	mustEmbedUnimplementedNodeRegistryServiceServer()
 }
 
@@ -98,9 +123,15 @@ type UnimplementedNodeRegistryServiceServer struct {
 func (UnimplementedNodeRegistryServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedNodeRegistryServiceServer) Deregister(context.Context, *DeregisterRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deregister not implemented")
+}
 func (UnimplementedNodeRegistryServiceServer) OpenChannel(NodeRegistryService_OpenChannelServer) error {
 	return status.Errorf(codes.Unimplemented, "method OpenChannel not implemented")
 }
+func (UnimplementedNodeRegistryServiceServer) ListNodeLiveness(context.Context, *ListNodeLivenessRequest) (*ListNodeLivenessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNodeLiveness not implemented")
+}
 func (UnimplementedNodeRegistryServiceServer) mustEmbedUnimplementedNodeRegistryServiceServer() {}
 
 // UnsafeNodeRegistryServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -132,6 +163,24 @@ func _NodeRegistryService_Register_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeRegistryService_Deregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryServiceServer).Deregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryService_Deregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryServiceServer).Deregister(ctx, req.(*DeregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeRegistryService_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(NodeRegistryServiceServer).OpenChannel(&nodeRegistryServiceOpenChannelServer{stream})
 }
@@ -158,6 +207,24 @@ func (x *nodeRegistryServiceOpenChannelServer) Recv() (*DeploymentConfiguration,
 	return m, nil
 }
 
+func _NodeRegistryService_ListNodeLiveness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeLivenessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeRegistryServiceServer).ListNodeLiveness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeRegistryService_ListNodeLiveness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeRegistryServiceServer).ListNodeLiveness(ctx, req.(*ListNodeLivenessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeRegistryService_ServiceDesc is the grpc.ServiceDesc for NodeRegistryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -169,6 +236,14 @@ var NodeRegistryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _NodeRegistryService_Register_Handler,
 		},
+		{
+			MethodName: "Deregister",
+			Handler:    _NodeRegistryService_Deregister_Handler,
+		},
+		{
+			MethodName: "ListNodeLiveness",
+			Handler:    _NodeRegistryService_ListNodeLiveness_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -23,7 +23,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ServiceRegistryService_OpenChannel_FullMethodName = "/carisma.service.v1.ServiceRegistryService/OpenChannel"
+	ServiceRegistryService_OpenChannel_FullMethodName  = "/carisma.service.v1.ServiceRegistryService/OpenChannel"
+	ServiceRegistryService_ReportHealth_FullMethodName = "/carisma.service.v1.ServiceRegistryService/ReportHealth"
+	ServiceRegistryService_ReportStats_FullMethodName  = "/carisma.service.v1.ServiceRegistryService/ReportStats"
 )
 
 // ServiceRegistryServiceClient is the client API for ServiceRegistryService service.
@@ -31,6 +33,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServiceRegistryServiceClient interface {
 	OpenChannel(ctx context.Context, opts ...grpc.CallOption) (ServiceRegistryService_OpenChannelClient, error)
+	ReportHealth(ctx context.Context, in *HealthReport, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReportStats(ctx context.Context, opts ...grpc.CallOption) (ServiceRegistryService_ReportStatsClient, error)
 }
 
 type serviceRegistryServiceClient struct {
@@ -75,11 +79,56 @@ func (x *serviceRegistryServiceOpenChannelClient) CloseAndRecv() (*emptypb.Empty
 	return m, nil
 }
 
+func (c *serviceRegistryServiceClient) ReportHealth(ctx context.Context, in *HealthReport, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ServiceRegistryService_ReportHealth_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceRegistryServiceClient) ReportStats(ctx context.Context, opts ...grpc.CallOption) (ServiceRegistryService_ReportStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ServiceRegistryService_ServiceDesc.Streams[1], ServiceRegistryService_ReportStats_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceRegistryServiceReportStatsClient{stream}
+	return x, nil
+}
+
+type ServiceRegistryService_ReportStatsClient interface {
+	Send(*StatsReport) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
+}
+
+type serviceRegistryServiceReportStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceRegistryServiceReportStatsClient) Send(m *StatsReport) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceRegistryServiceReportStatsClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServiceRegistryServiceServer is the server API for ServiceRegistryService service.
 // All implementations must embed UnimplementedServiceRegistryServiceServer
 // for forward compatibility
 type ServiceRegistryServiceServer interface {
 	OpenChannel(ServiceRegistryService_OpenChannelServer) error
+	ReportHealth(context.Context, *HealthReport) (*emptypb.Empty, error)
+	ReportStats(ServiceRegistryService_ReportStatsServer) error
 	mustEmbedUnimplementedServiceRegistryServiceServer()
 }
 
@@ -90,6 +139,12 @@ type UnimplementedServiceRegistryServiceServer struct {
 func (UnimplementedServiceRegistryServiceServer) OpenChannel(ServiceRegistryService_OpenChannelServer) error {
 	return status.Errorf(codes.Unimplemented, "method OpenChannel not implemented")
 }
+func (UnimplementedServiceRegistryServiceServer) ReportHealth(context.Context, *HealthReport) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportHealth not implemented")
+}
+func (UnimplementedServiceRegistryServiceServer) ReportStats(ServiceRegistryService_ReportStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReportStats not implemented")
+}
 func (UnimplementedServiceRegistryServiceServer) mustEmbedUnimplementedServiceRegistryServiceServer() {
 }
 
@@ -130,19 +185,73 @@ func (x *serviceRegistryServiceOpenChannelServer) Recv() (*ServiceAnnouncement,
 	return m, nil
 }
 
+func _ServiceRegistryService_ReportHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceRegistryServiceServer).ReportHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServiceRegistryService_ReportHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceRegistryServiceServer).ReportHealth(ctx, req.(*HealthReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceRegistryService_ReportStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceRegistryServiceServer).ReportStats(&serviceRegistryServiceReportStatsServer{stream})
+}
+
+type ServiceRegistryService_ReportStatsServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*StatsReport, error)
+	grpc.ServerStream
+}
+
+type serviceRegistryServiceReportStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceRegistryServiceReportStatsServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceRegistryServiceReportStatsServer) Recv() (*StatsReport, error) {
+	m := new(StatsReport)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ServiceRegistryService_ServiceDesc is the grpc.ServiceDesc for ServiceRegistryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var ServiceRegistryService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "carisma.service.v1.ServiceRegistryService",
 	HandlerType: (*ServiceRegistryServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportHealth",
+			Handler:    _ServiceRegistryService_ReportHealth_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "OpenChannel",
 			Handler:       _ServiceRegistryService_OpenChannel_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReportStats",
+			Handler:       _ServiceRegistryService_ReportStats_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "carisma/service/v1/service.proto",
 }
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+//Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.29.3
+// source: carisma/traffic/v1/traffic.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TrafficService_SetWeights_FullMethodName  = "/carisma.traffic.v1.TrafficService/SetWeights"
+	TrafficService_ListWeights_FullMethodName = "/carisma.traffic.v1.TrafficService/ListWeights"
+)
+
+// TrafficServiceClient is the client API for TrafficService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TrafficServiceClient interface {
+	SetWeights(ctx context.Context, in *SetWeightsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListWeights(ctx context.Context, in *ListWeightsRequest, opts ...grpc.CallOption) (*ListWeightsResponse, error)
+}
+
+type trafficServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrafficServiceClient(cc grpc.ClientConnInterface) TrafficServiceClient {
+	return &trafficServiceClient{cc}
+}
+
+func (c *trafficServiceClient) SetWeights(ctx context.Context, in *SetWeightsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TrafficService_SetWeights_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trafficServiceClient) ListWeights(ctx context.Context, in *ListWeightsRequest, opts ...grpc.CallOption) (*ListWeightsResponse, error) {
+	out := new(ListWeightsResponse)
+	err := c.cc.Invoke(ctx, TrafficService_ListWeights_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TrafficServiceServer is the server API for TrafficService service.
+// All implementations must embed UnimplementedTrafficServiceServer
+// for forward compatibility
+type TrafficServiceServer interface {
+	SetWeights(context.Context, *SetWeightsRequest) (*emptypb.Empty, error)
+	ListWeights(context.Context, *ListWeightsRequest) (*ListWeightsResponse, error)
+	mustEmbedUnimplementedTrafficServiceServer()
+}
+
+// UnimplementedTrafficServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTrafficServiceServer struct {
+}
+
+func (UnimplementedTrafficServiceServer) SetWeights(context.Context, *SetWeightsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWeights not implemented")
+}
+func (UnimplementedTrafficServiceServer) ListWeights(context.Context, *ListWeightsRequest) (*ListWeightsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWeights not implemented")
+}
+func (UnimplementedTrafficServiceServer) mustEmbedUnimplementedTrafficServiceServer() {}
+
+// UnsafeTrafficServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TrafficServiceServer will
+// result in compilation errors.
+type UnsafeTrafficServiceServer interface {
+	mustEmbedUnimplementedTrafficServiceServer()
+}
+
+func RegisterTrafficServiceServer(s grpc.ServiceRegistrar, srv TrafficServiceServer) {
+	s.RegisterService(&TrafficService_ServiceDesc, srv)
+}
+
+func _TrafficService_SetWeights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWeightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).SetWeights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_SetWeights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).SetWeights(ctx, req.(*SetWeightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrafficService_ListWeights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWeightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrafficServiceServer).ListWeights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrafficService_ListWeights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrafficServiceServer).ListWeights(ctx, req.(*ListWeightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TrafficService_ServiceDesc is the grpc.ServiceDesc for TrafficService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TrafficService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "carisma.traffic.v1.TrafficService",
+	HandlerType: (*TrafficServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetWeights",
+			Handler:    _TrafficService_SetWeights_Handler,
+		},
+		{
+			MethodName: "ListWeights",
+			Handler:    _TrafficService_ListWeights_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "carisma/traffic/v1/traffic.proto",
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+// Package ratelimit implements the rate limit service of Envoy that enforces the global rate limits of the bundles,
+// i.e., the limits that are shared by all nodes of the mesh.
+package ratelimit
+
+import (
+	"context"
+	ratelimitcommon "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	pbRLS "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+const (
+	// Domain of the rate limit requests sent by Envoy.
+	Domain = "carisma"
+
+	// Keys of the descriptor entries that identify the called bundle and the calling bundle.
+	DescriptorKeyBundle = "bundle"
+	DescriptorKeyCaller = "caller"
+
+	// Rate limits are counted in fixed windows of this duration.
+	window = time.Second
+
+	errUnknownDomain = "unknown rate limit domain"
+)
+
+// LimitFunc returns the rate limit of the provided bundle, if any.
+type LimitFunc func(bundleID string) *config.RateLimit
+
+// Server counts the requests to the bundles of all nodes and tells Envoy whether a request exceeds the rate limit of the
+// called bundle or of the calling bundle.
+type Server struct {
+	pbRLS.UnimplementedRateLimitServiceServer
+
+	limit LimitFunc
+	now   func() time.Time
+
+	mu          sync.Mutex // protects windowStart and hits
+	windowStart time.Time
+	hits        map[string]uint32
+}
+
+// NewServer creates a new instance of Server that looks up the rate limits via the provided function.
+func NewServer(limit LimitFunc) *Server {
+	return &Server{
+		limit: limit,
+		now:   time.Now,
+		hits:  make(map[string]uint32),
+	}
+}
+
+// descriptorLimit returns the limit of the provided descriptor, which consists of the called bundle and optionally the
+// calling bundle, and the key its hits are counted by. A limit of zero denotes an unlimited descriptor.
+func (s *Server) descriptorLimit(descriptor *ratelimitcommon.RateLimitDescriptor) (string, uint32) {
+	var bundleID, caller string
+	for _, entry := range descriptor.GetEntries() {
+		switch entry.Key {
+		case DescriptorKeyBundle:
+			bundleID = entry.Value
+		case DescriptorKeyCaller:
+			caller = entry.Value
+		}
+	}
+
+	limit := s.limit(bundleID)
+	if bundleID == "" || limit == nil {
+		return "", 0
+	}
+
+	if caller == "" {
+		return bundleID, limit.RequestsPerSecond
+	}
+
+	return bundleID + "/" + caller, limit.PerCaller[caller]
+}
+
+// ShouldRateLimit counts the hits of the descriptors of the provided request and rejects the request if one of them
+// exceeds its limit within the current window.
+func (s *Server) ShouldRateLimit(_ context.Context, req *pbRLS.RateLimitRequest) (*pbRLS.RateLimitResponse, error) {
+	if req.Domain != Domain {
+		return nil, status.Error(codes.InvalidArgument, errUnknownDomain)
+	}
+
+	hits := req.HitsAddend
+	if hits == 0 {
+		hits = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if start := s.now().Truncate(window); !start.Equal(s.windowStart) {
+		s.windowStart = start
+		clear(s.hits)
+	}
+
+	resp := &pbRLS.RateLimitResponse{
+		OverallCode: pbRLS.RateLimitResponse_OK,
+		Statuses:    make([]*pbRLS.RateLimitResponse_DescriptorStatus, len(req.Descriptors)),
+	}
+
+	for idx, descriptor := range req.Descriptors {
+		resp.Statuses[idx] = &pbRLS.RateLimitResponse_DescriptorStatus{Code: pbRLS.RateLimitResponse_OK}
+
+		key, limit := s.descriptorLimit(descriptor)
+		if limit == 0 {
+			continue
+		}
+
+		s.hits[key] += hits
+
+		resp.Statuses[idx].CurrentLimit = &pbRLS.RateLimitResponse_RateLimit{
+			RequestsPerUnit: limit,
+			Unit:            pbRLS.RateLimitResponse_RateLimit_SECOND,
+		}
+
+		if s.hits[key] > limit {
+			resp.Statuses[idx].Code = pbRLS.RateLimitResponse_OVER_LIMIT
+			resp.OverallCode = pbRLS.RateLimitResponse_OVER_LIMIT
+		} else {
+			resp.Statuses[idx].LimitRemaining = limit - s.hits[key]
+		}
+	}
+
+	return resp, nil
+}
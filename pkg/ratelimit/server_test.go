@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package ratelimit
+
+import (
+	"context"
+	ratelimitcommon "github.com/envoyproxy/go-control-plane/envoy/extensions/common/ratelimit/v3"
+	pbRLS "github.com/envoyproxy/go-control-plane/envoy/service/ratelimit/v3"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"gotest.tools/v3/assert"
+	"testing"
+	"time"
+)
+
+// newRequest creates a request like Envoy does, i.e., with a descriptor of the called bundle and, if the caller is
+// known, a descriptor of the called and the calling bundle.
+func newRequest(bundleID, caller string) *pbRLS.RateLimitRequest {
+	bundleEntry := &ratelimitcommon.RateLimitDescriptor_Entry{Key: DescriptorKeyBundle, Value: bundleID}
+
+	req := &pbRLS.RateLimitRequest{
+		Domain:      Domain,
+		Descriptors: []*ratelimitcommon.RateLimitDescriptor{{Entries: []*ratelimitcommon.RateLimitDescriptor_Entry{bundleEntry}}},
+	}
+
+	if caller != "" {
+		req.Descriptors = append(req.Descriptors, &ratelimitcommon.RateLimitDescriptor{
+			Entries: []*ratelimitcommon.RateLimitDescriptor_Entry{
+				bundleEntry,
+				{Key: DescriptorKeyCaller, Value: caller},
+			},
+		})
+	}
+
+	return req
+}
+
+func TestShouldRateLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	s := NewServer(func(bundleID string) *config.RateLimit {
+		if bundleID != "navigation" {
+			return nil
+		}
+
+		return &config.RateLimit{RequestsPerSecond: 3, PerCaller: map[string]uint32{"infotainment": 1}, Global: true}
+	})
+	s.now = func() time.Time { return now }
+
+	shouldRateLimit := func(req *pbRLS.RateLimitRequest) pbRLS.RateLimitResponse_Code {
+		resp, err := s.ShouldRateLimit(context.Background(), req)
+		assert.NilError(t, err)
+
+		return resp.OverallCode
+	}
+
+	assert.Equal(t, shouldRateLimit(newRequest("navigation", "infotainment")), pbRLS.RateLimitResponse_OK)
+	assert.Equal(t, shouldRateLimit(newRequest("navigation", "infotainment")), pbRLS.RateLimitResponse_OVER_LIMIT)
+
+	// callers without a limit of their own only count towards the limit of the bundle
+	assert.Equal(t, shouldRateLimit(newRequest("navigation", "telematics")), pbRLS.RateLimitResponse_OK)
+	assert.Equal(t, shouldRateLimit(newRequest("navigation", "")), pbRLS.RateLimitResponse_OVER_LIMIT)
+
+	// bundles without a limit are never limited
+	assert.Equal(t, shouldRateLimit(newRequest("radio", "infotainment")), pbRLS.RateLimitResponse_OK)
+
+	// the hits are reset in the next window
+	now = now.Add(window)
+	assert.Equal(t, shouldRateLimit(newRequest("navigation", "")), pbRLS.RateLimitResponse_OK)
+
+	_, err := s.ShouldRateLimit(context.Background(), &pbRLS.RateLimitRequest{Domain: "other"})
+	assert.ErrorContains(t, err, errUnknownDomain)
+}
@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderAdmissionToken = "x-carisma-admission-token"
+	HeaderNodeToken      = "x-carisma-node-token"
+
+	// Prefix of the full method names of all CARISMA gRPC services.
+	carismaMethodPrefix = "/carisma."
+
+	registerMethod = "/carisma.node.v1.NodeRegistryService/Register"
+
+	// Prefixes of the full method names of the administrative services, which expose or change the state of all nodes.
+	replicationMethodPrefix = "/carisma.replication.v1."
+	trafficMethodPrefix     = "/carisma.traffic.v1."
+	faultMethodPrefix       = "/carisma.fault.v1."
+
+	sessionKeySize = 32
+)
+
+// Authenticator admits nodes to the control plane and authenticates their subsequent requests. A node is admitted if it
+// presents a client certificate issued by the certificate authority of the control plane or the pre-shared admission
+// token. Upon registration, the node receives a token that binds its node ID to its subsequent requests.
+type Authenticator struct {
+	admissionToken string
+	sessionKey     []byte
+
+	ca      *pki.CA
+	certTTL time.Duration
+}
+
+// NewAuthenticator creates a new instance of Authenticator and loads the key for signing node tokens from the provided
+// file path, or creates it if not present. If a certificate authority is provided, registered nodes receive a client
+// certificate that is valid for the provided duration.
+func NewAuthenticator(admissionToken, sessionKeyFilePath string, ca *pki.CA, certTTL time.Duration) (*Authenticator, error) {
+	sessionKey, err := os.ReadFile(sessionKeyFilePath)
+	if os.IsNotExist(err) {
+		sessionKey = make([]byte, sessionKeySize)
+		if _, err := rand.Read(sessionKey); err != nil {
+			return nil, err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(sessionKeyFilePath), 0700); err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(sessionKeyFilePath, sessionKey, 0600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else if len(sessionKey) < sessionKeySize {
+		return nil, errors.New("session key too short")
+	}
+
+	return &Authenticator{
+		admissionToken: admissionToken,
+		sessionKey:     sessionKey,
+		ca:             ca,
+		certTTL:        certTTL,
+	}, nil
+}
+
+// AdmissionRestricted checks whether nodes have to present credentials in order to register.
+func (a *Authenticator) AdmissionRestricted() bool {
+	return a.admissionToken != "" || a.ca != nil
+}
+
+// NodeToken returns the token that authenticates the requests of the node with the provided ID.
+func (a *Authenticator) NodeToken(nodeID string) string {
+	mac := hmac.New(sha256.New, a.sessionKey)
+	mac.Write([]byte(nodeID))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NodeCertificate issues a client certificate for the node with the provided ID, if a certificate authority is present.
+func (a *Authenticator) NodeCertificate(nodeID, hostname string) (*pki.Certificate, error) {
+	if a.ca == nil {
+		return nil, nil
+	}
+
+	return a.ca.Issue(nodeID, []string{hostname}, []*url.URL{pki.NodeIdentity(nodeID)}, a.certTTL)
+}
+
+// Admit checks whether the caller is allowed to register as node or to use the administrative APIs.
+func (a *Authenticator) Admit(ctx context.Context) error {
+	if !a.AdmissionRestricted() {
+		return nil
+	}
+
+	if peerCertificate(ctx) != nil {
+		return nil
+	}
+
+	if a.admissionToken != "" {
+		if token := firstHeaderValue(ctx, HeaderAdmissionToken); subtle.ConstantTimeCompare([]byte(token), []byte(a.admissionToken)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, errorMsgNotAdmitted)
+}
+
+// AuthenticateNode checks whether the caller is the node with the provided ID, i.e., whether it presents a client
+// certificate or a node token issued for this node.
+func (a *Authenticator) AuthenticateNode(ctx context.Context, nodeID string) error {
+	if certNodeID, ok := CertificateNodeID(ctx); ok {
+		if certNodeID != nodeID {
+			return status.Error(codes.PermissionDenied, errorMsgNodeIDMismatch)
+		}
+
+		return nil
+	}
+
+	token := firstHeaderValue(ctx, HeaderNodeToken)
+	if !hmac.Equal([]byte(token), []byte(a.NodeToken(nodeID))) {
+		return status.Error(codes.Unauthenticated, errorMsgInvalidNodeToken)
+	}
+
+	return nil
+}
+
+// AdmitAdministrator checks whether the caller is allowed to use the administrative APIs, e.g., the replication of the
+// registry to the peer control plane. Node tokens are never accepted and node certificates only identify ordinary nodes.
+func (a *Authenticator) AdmitAdministrator(ctx context.Context) error {
+	if _, ok := CertificateNodeID(ctx); ok {
+		return status.Error(codes.PermissionDenied, errorMsgNodeNotAdministrator)
+	}
+
+	return a.Admit(ctx)
+}
+
+// authorize authenticates a call of a CARISMA gRPC method. Calls that present a node ID are bound to this node,
+// registrations and administrative calls require admission. Calls of other services, e.g., xDS, are not affected.
+func (a *Authenticator) authorize(ctx context.Context, fullMethod string) error {
+	if !strings.HasPrefix(fullMethod, carismaMethodPrefix) {
+		return nil
+	}
+
+	if isAdministrativeMethod(fullMethod) {
+		return a.AdmitAdministrator(ctx)
+	}
+
+	if fullMethod != registerMethod {
+		if nodeID := firstHeaderValue(ctx, HeaderNodeID); nodeID != "" {
+			return a.AuthenticateNode(ctx, nodeID)
+		}
+	}
+
+	return a.Admit(ctx)
+}
+
+// UnaryServerInterceptor returns an interceptor that authenticates unary calls.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that authenticates streaming calls.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func isAdministrativeMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, replicationMethodPrefix) ||
+		strings.HasPrefix(fullMethod, trafficMethodPrefix) ||
+		strings.HasPrefix(fullMethod, faultMethodPrefix)
+}
+
+// CertificateNodeID returns the node ID encoded in the verified client certificate of the caller, if any.
+func CertificateNodeID(ctx context.Context) (string, bool) {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return "", false
+	}
+
+	for _, uri := range cert.URIs {
+		if nodeID, ok := pki.NodeIDFromIdentity(uri); ok {
+			return nodeID, true
+		}
+	}
+
+	return "", false
+}
+
+// peerCertificate returns the verified client certificate of the caller, if any.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0]
+}
+
+func firstHeaderValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
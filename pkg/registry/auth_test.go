@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/pki"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/node/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestAuthenticator(t *testing.T, admissionToken string, ca *pki.CA) *Authenticator {
+	auth, err := NewAuthenticator(admissionToken, filepath.Join(t.TempDir(), "private", "session.key"), ca, time.Hour)
+	assert.NilError(t, err)
+
+	return auth
+}
+
+// contextWithCertificate creates an incoming context whose peer presented a verified client certificate issued by the
+// provided certificate authority. The certificate identifies the node with the provided ID, or no node if it is empty,
+// e.g., the certificate of the peer control plane.
+func contextWithCertificate(t *testing.T, ca *pki.CA, nodeID string) context.Context {
+	var uris []*url.URL
+	if nodeID != "" {
+		uris = []*url.URL{pki.NodeIdentity(nodeID)}
+	}
+
+	c, err := ca.Issue("carisma-test", nil, uris, time.Hour)
+	assert.NilError(t, err)
+
+	block, _ := pem.Decode(c.CertificatePEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NilError(t, err)
+
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		},
+	})
+}
+
+func TestAdmit(t *testing.T) {
+	auth := newTestAuthenticator(t, "secret", nil)
+
+	err := auth.Admit(context.Background())
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	err = auth.Admit(metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderAdmissionToken, "guess")))
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	err = auth.Admit(metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderAdmissionToken, "secret")))
+	assert.NilError(t, err)
+
+	// admission is not restricted without token and certificate authority
+	assert.NilError(t, newTestAuthenticator(t, "", nil).Admit(context.Background()))
+}
+
+func TestAdmitWithCertificate(t *testing.T) {
+	ca, err := pki.NewCA()
+	assert.NilError(t, err)
+
+	auth := newTestAuthenticator(t, "", ca)
+
+	assert.Equal(t, status.Code(auth.Admit(context.Background())), codes.Unauthenticated)
+	assert.NilError(t, auth.Admit(contextWithCertificate(t, ca, "node-hpc-1")))
+}
+
+func TestAuthenticateNode(t *testing.T) {
+	auth := newTestAuthenticator(t, "secret", nil)
+
+	token := auth.NodeToken("node-hpc-1")
+
+	err := auth.AuthenticateNode(metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderNodeToken, token)), "node-hpc-1")
+	assert.NilError(t, err)
+
+	// the token of one node does not authenticate another node
+	err = auth.AuthenticateNode(metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderNodeToken, token)), "node-hpc-2")
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	err = auth.AuthenticateNode(context.Background(), "node-hpc-1")
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+}
+
+func TestAuthenticateNodeWithCertificate(t *testing.T) {
+	ca, err := pki.NewCA()
+	assert.NilError(t, err)
+
+	auth := newTestAuthenticator(t, "", ca)
+
+	ctx := contextWithCertificate(t, ca, "node-hpc-1")
+	assert.NilError(t, auth.AuthenticateNode(ctx, "node-hpc-1"))
+	assert.Equal(t, status.Code(auth.AuthenticateNode(ctx, "node-hpc-2")), codes.PermissionDenied)
+}
+
+func TestNodeTokenSurvivesRestart(t *testing.T) {
+	sessionKeyFilePath := filepath.Join(t.TempDir(), "session.key")
+
+	auth, err := NewAuthenticator("", sessionKeyFilePath, nil, time.Hour)
+	assert.NilError(t, err)
+
+	restoredAuth, err := NewAuthenticator("", sessionKeyFilePath, nil, time.Hour)
+	assert.NilError(t, err)
+
+	assert.Equal(t, restoredAuth.NodeToken("node-hpc-1"), auth.NodeToken("node-hpc-1"))
+}
+
+func TestAuthorize(t *testing.T) {
+	auth := newTestAuthenticator(t, "secret", nil)
+
+	// xDS streams are not affected
+	assert.NilError(t, auth.authorize(context.Background(), "/envoy.service.discovery.v3.AggregatedDiscoveryService/StreamAggregatedResources"))
+
+	assert.Equal(t, status.Code(auth.authorize(context.Background(), registerMethod)), codes.Unauthenticated)
+
+	// the node ID header binds the call to the node
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderNodeID, "node-hpc-1", HeaderAdmissionToken, "secret"))
+	err := auth.authorize(ctx, "/carisma.service.v1.ServiceRegistryService/OpenChannel")
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+}
+
+func TestAuthorizeAdministrativeMethods(t *testing.T) {
+	ca, err := pki.NewCA()
+	assert.NilError(t, err)
+
+	auth := newTestAuthenticator(t, "secret", ca)
+	nodeMD := metadata.Pairs(HeaderNodeID, "node-hpc-1", HeaderNodeToken, auth.NodeToken("node-hpc-1"))
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{
+			name:     "node token",
+			ctx:      metadata.NewIncomingContext(context.Background(), nodeMD),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "node certificate",
+			ctx:      metadata.NewIncomingContext(contextWithCertificate(t, ca, "node-hpc-1"), nodeMD),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "admission token",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderAdmissionToken, "secret")),
+		},
+		{
+			name: "peer certificate",
+			ctx:  contextWithCertificate(t, ca, ""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, method := range []string{
+				"/carisma.replication.v1.ReplicationService/Replicate",
+				"/carisma.traffic.v1.TrafficService/SetWeights",
+				"/carisma.fault.v1.FaultService/InjectFault",
+			} {
+				assert.Equal(t, status.Code(auth.authorize(test.ctx, method)), test.wantCode, method)
+			}
+		})
+	}
+}
+
+func TestRegisterIssuesCredentials(t *testing.T) {
+	ca, err := pki.NewCA()
+	assert.NilError(t, err)
+
+	s, chanNodes := newTestNodeRegistryServer(t)
+	s.UseAuthenticator(newTestAuthenticator(t, "", ca))
+
+	r, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "10.0.0.1", Port: 8000, Hostname: "hpc-1"})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	assert.Equal(t, r.Token, s.auth.NodeToken(r.Id))
+
+	c := &pki.Certificate{CertificatePEM: r.Certificate, PrivateKeyPEM: r.PrivateKey}
+	_, err = c.TLSCertificate()
+	assert.NilError(t, err)
+
+	// a node certificate cannot be used to register another node
+	_, err = s.Register(contextWithCertificate(t, ca, r.Id), &pb.RegisterRequest{Address: "10.0.0.2", Port: 8000, Hostname: "hpc-2"})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	// a certified node can only register again with its certificate, the admission token only admits unknown nodes
+	_, err = s.Register(context.Background(), &pb.RegisterRequest{Address: "10.0.0.3", Port: 8000, Hostname: "hpc-1"})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	_, err = s.Register(contextWithCertificate(t, ca, r.Id), &pb.RegisterRequest{Address: "10.0.0.1", Port: 8000, Hostname: "hpc-1"})
+	assert.NilError(t, err)
+	nodes := <-chanNodes
+
+	assert.Check(t, nodes[r.Id].Certified)
+	assert.DeepEqual(t, nodes[r.Id].Addr, &NodeAddr{Host: "10.0.0.1", Port: 8000})
+}
@@ -4,6 +4,24 @@
 package registry
 
 const (
-	errorMsgMissingHeader = "CARISMA node ID header missing"
-	errorInvalidNodeID    = "presented node ID unknown"
+	errorMsgMissingHeader    = "CARISMA node ID header missing"
+	errorInvalidNodeID       = "presented node ID unknown"
+	errorMsgMissingBundleID  = "bundle ID missing"
+	errorMsgZeroWeights      = "at least one traffic weight must be greater than zero"
+	errorMsgExcessiveWeights = "sum of the traffic weights must not exceed 4294967295"
+	errorMsgNotAdmitted      = "node not admitted, client certificate or admission token required"
+	errorMsgInvalidNodeToken = "node token missing or invalid"
+	errorMsgNodeIDMismatch   = "presented node ID does not match authenticated identity"
+	errorMsgNodeIDTaken      = "node ID already registered for a different hostname"
+
+	errorMsgCertificateRequired  = "node certificate required to register a known node again"
+	errorMsgNodeNotAdministrator = "nodes are not allowed to use administrative APIs"
+
+	errorMsgInvalidFaultPath     = "fault path prefix must start with '/'"
+	errorMsgInvalidFaultPercent  = "fault percentages must not exceed 100"
+	errorMsgInvalidFaultStatus   = "invalid gRPC status code of fault abort"
+	errorMsgNoFault              = "fault requires a delay or an abort with a positive percentage"
+	errorMsgMissingFaultDuration = "fault duration missing"
+	errorMsgDuplicateFault       = "fault for bundle and path prefix already active"
+	errorMsgUnknownFault         = "fault unknown or already expired"
 )
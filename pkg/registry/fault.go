@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/fault/v1"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fault encodes the delays and aborts injected into the requests to a bundle until the fault expires.
+type Fault struct {
+	ID              string
+	BundleID        string
+	PathPrefix      string
+	DelayMs         uint32
+	DelayPercent    uint32
+	AbortGRPCStatus uint32
+	AbortPercent    uint32
+	Expires         time.Time
+}
+
+func (f Fault) toProto() *pb.Fault {
+	return &pb.Fault{
+		FaultId:         f.ID,
+		BundleId:        f.BundleID,
+		PathPrefix:      f.PathPrefix,
+		DelayMs:         f.DelayMs,
+		DelayPercent:    f.DelayPercent,
+		AbortGrpcStatus: f.AbortGRPCStatus,
+		AbortPercent:    f.AbortPercent,
+		ExpiresAt:       timestamppb.New(f.Expires),
+	}
+}
+
+// FaultSnapshot represents a mapping of fault IDs to the active faults at one point in time.
+type FaultSnapshot map[string]Fault
+
+// Clone creates a copy of the snapshot.
+func (f FaultSnapshot) Clone() FaultSnapshot {
+	return maps.Clone(f)
+}
+
+// FaultServer implements the fault server that injects faults into the requests to the bundles for resilience testing.
+// Faults are not persisted and expire automatically, so that a forgotten experiment cannot remain active.
+type FaultServer struct {
+	pb.UnimplementedFaultServiceServer
+
+	mu     *sync.RWMutex // protects faults, timers and nextID
+	faults FaultSnapshot
+	timers map[string]*time.Timer
+	nextID int
+
+	maxDuration time.Duration
+
+	publisher publisher[FaultSnapshot]
+}
+
+func validateFault(req *pb.InjectFaultRequest) error {
+	if req.BundleId == "" {
+		return status.Error(codes.InvalidArgument, errorMsgMissingBundleID)
+	}
+
+	if req.PathPrefix != "" && !strings.HasPrefix(req.PathPrefix, "/") {
+		return status.Error(codes.InvalidArgument, errorMsgInvalidFaultPath)
+	}
+
+	if req.DelayPercent > 100 || req.AbortPercent > 100 {
+		return status.Error(codes.InvalidArgument, errorMsgInvalidFaultPercent)
+	}
+
+	delays := req.DelayMs > 0 && req.DelayPercent > 0
+	aborts := req.AbortGrpcStatus > 0 && req.AbortPercent > 0
+	if !delays && !aborts {
+		return status.Error(codes.InvalidArgument, errorMsgNoFault)
+	}
+
+	if req.AbortGrpcStatus > uint32(codes.Unauthenticated) {
+		return status.Error(codes.InvalidArgument, errorMsgInvalidFaultStatus)
+	}
+
+	if req.DurationSeconds == 0 {
+		return status.Error(codes.InvalidArgument, errorMsgMissingFaultDuration)
+	}
+
+	return nil
+}
+
+// InjectFault injects a fault into the requests to a bundle, optionally restricted to the requests with a path prefix.
+// The fault expires after the requested duration, but at most after the maximum duration of the server.
+func (f *FaultServer) InjectFault(_ context.Context, req *pb.InjectFaultRequest) (*pb.Fault, error) {
+	if err := validateFault(req); err != nil {
+		return nil, err
+	}
+
+	duration := min(time.Duration(req.DurationSeconds)*time.Second, f.maxDuration)
+
+	f.mu.Lock()
+
+	for _, active := range f.faults {
+		if active.BundleID == req.BundleId && active.PathPrefix == req.PathPrefix {
+			f.mu.Unlock()
+
+			return nil, status.Error(codes.AlreadyExists, errorMsgDuplicateFault)
+		}
+	}
+
+	f.nextID++
+
+	fault := Fault{
+		ID:              fmt.Sprintf("%s-%d", req.BundleId, f.nextID),
+		BundleID:        req.BundleId,
+		PathPrefix:      req.PathPrefix,
+		DelayMs:         req.DelayMs,
+		DelayPercent:    req.DelayPercent,
+		AbortGRPCStatus: req.AbortGrpcStatus,
+		AbortPercent:    req.AbortPercent,
+		Expires:         time.Now().Add(duration),
+	}
+
+	f.faults[fault.ID] = fault
+	f.timers[fault.ID] = time.AfterFunc(duration, func() {
+		if f.removeFault(fault.ID) {
+			logging.DefaultLogger.Info().
+				Str("Fault-ID", fault.ID).
+				Str("Bundle-ID", fault.BundleID).
+				Msg("Fault expired")
+		}
+	})
+
+	f.mu.Unlock()
+
+	logging.DefaultLogger.Info().
+		Str("Fault-ID", fault.ID).
+		Str("Bundle-ID", fault.BundleID).
+		Str("PathPrefix", fault.PathPrefix).
+		Time("Expires", fault.Expires).
+		Msg("Injected fault")
+
+	f.publishFaults()
+
+	return fault.toProto(), nil
+}
+
+// removeFault removes the fault with the provided ID and reports whether it was active.
+func (f *FaultServer) removeFault(faultID string) bool {
+	f.mu.Lock()
+
+	if _, ok := f.faults[faultID]; !ok {
+		f.mu.Unlock()
+
+		return false
+	}
+
+	delete(f.faults, faultID)
+
+	f.timers[faultID].Stop()
+	delete(f.timers, faultID)
+
+	f.mu.Unlock()
+
+	f.publishFaults()
+
+	return true
+}
+
+// publishFaults sends the active faults to the xDS server, e.g., once a fault expired. The caller must not hold the lock,
+// as the receiver acquires it.
+func (f *FaultServer) publishFaults() {
+	f.publisher.publish(func() FaultSnapshot {
+		f.mu.RLock()
+		defer f.mu.RUnlock()
+
+		return f.faults.Clone()
+	})
+}
+
+// RemoveFault removes an active fault before it expires.
+func (f *FaultServer) RemoveFault(_ context.Context, req *pb.RemoveFaultRequest) (*emptypb.Empty, error) {
+	if !f.removeFault(req.FaultId) {
+		return nil, status.Error(codes.NotFound, errorMsgUnknownFault)
+	}
+
+	logging.DefaultLogger.Info().
+		Str("Fault-ID", req.FaultId).
+		Msg("Removed fault")
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListFaults returns the active faults of all bundles or of the requested bundle.
+func (f *FaultServer) ListFaults(_ context.Context, req *pb.ListFaultsRequest) (*pb.ListFaultsResponse, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	faultIDs := maps.Keys(f.faults)
+	slices.Sort(faultIDs)
+
+	resp := &pb.ListFaultsResponse{Faults: make([]*pb.Fault, 0, len(faultIDs))}
+	for _, faultID := range faultIDs {
+		fault := f.faults[faultID]
+		if req.BundleId != "" && req.BundleId != fault.BundleID {
+			continue
+		}
+
+		resp.Faults = append(resp.Faults, fault.toProto())
+	}
+
+	return resp, nil
+}
+
+// NewFaultServer creates a new instance of the FaultServer whose faults expire after the provided maximum duration at
+// the latest.
+func NewFaultServer(mu *sync.RWMutex, uC chan<- FaultSnapshot, maxDuration time.Duration) *FaultServer {
+	return &FaultServer{
+		mu:          mu,
+		faults:      make(FaultSnapshot),
+		timers:      make(map[string]*time.Timer),
+		maxDuration: maxDuration,
+		publisher:   publisher[FaultSnapshot]{ch: uC},
+	}
+}
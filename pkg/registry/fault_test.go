@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/fault/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInjectFault(t *testing.T) {
+	chanFaults := make(chan FaultSnapshot, 10)
+	s := NewFaultServer(&sync.RWMutex{}, chanFaults, time.Hour)
+
+	fault, err := s.InjectFault(context.Background(), &pb.InjectFaultRequest{
+		BundleId:        testBundleID,
+		AbortGrpcStatus: uint32(codes.Unavailable),
+		AbortPercent:    50,
+		DurationSeconds: 60,
+	})
+	assert.NilError(t, err)
+
+	faults := <-chanFaults
+	assert.Equal(t, faults[fault.FaultId].AbortPercent, uint32(50))
+
+	// a bundle and path prefix can only be the target of one fault at a time
+	_, err = s.InjectFault(context.Background(), &pb.InjectFaultRequest{
+		BundleId:        testBundleID,
+		DelayMs:         100,
+		DelayPercent:    100,
+		DurationSeconds: 60,
+	})
+	assert.Equal(t, status.Code(err), codes.AlreadyExists)
+
+	resp, err := s.ListFaults(context.Background(), &pb.ListFaultsRequest{BundleId: testBundleID})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Faults), 1)
+
+	_, err = s.RemoveFault(context.Background(), &pb.RemoveFaultRequest{FaultId: fault.FaultId})
+	assert.NilError(t, err)
+
+	faults = <-chanFaults
+	assert.Equal(t, len(faults), 0)
+
+	_, err = s.RemoveFault(context.Background(), &pb.RemoveFaultRequest{FaultId: fault.FaultId})
+	assert.Equal(t, status.Code(err), codes.NotFound)
+}
+
+func TestInjectFaultExpires(t *testing.T) {
+	chanFaults := make(chan FaultSnapshot, 10)
+	s := NewFaultServer(&sync.RWMutex{}, chanFaults, 10*time.Millisecond)
+
+	// the requested duration is limited by the maximum duration
+	_, err := s.InjectFault(context.Background(), &pb.InjectFaultRequest{
+		BundleId:        testBundleID,
+		PathPrefix:      "/Y.Service/Method",
+		DelayMs:         100,
+		DelayPercent:    100,
+		DurationSeconds: 3600,
+	})
+	assert.NilError(t, err)
+	<-chanFaults
+
+	faults := <-chanFaults
+	assert.Equal(t, len(faults), 0)
+}
+
+func TestInjectFaultInvalid(t *testing.T) {
+	s := NewFaultServer(&sync.RWMutex{}, make(chan FaultSnapshot, 10), time.Hour)
+
+	_, err := s.InjectFault(context.Background(), &pb.InjectFaultRequest{BundleId: testBundleID, DurationSeconds: 60})
+	assert.ErrorContains(t, err, errorMsgNoFault)
+
+	_, err = s.InjectFault(context.Background(), &pb.InjectFaultRequest{BundleId: testBundleID, DelayMs: 100, DelayPercent: 100})
+	assert.ErrorContains(t, err, errorMsgMissingFaultDuration)
+
+	_, err = s.InjectFault(context.Background(), &pb.InjectFaultRequest{BundleId: testBundleID, AbortGrpcStatus: 99, AbortPercent: 100, DurationSeconds: 60})
+	assert.ErrorContains(t, err, errorMsgInvalidFaultStatus)
+}
+
+func TestConcurrentFaultsPublishLatestFaults(t *testing.T) {
+	mu := &sync.RWMutex{}
+	chanFaults := make(chan FaultSnapshot, 10)
+	s := NewFaultServer(mu, chanFaults, time.Hour)
+
+	const numFaults = 20
+
+	var wg sync.WaitGroup
+	wg.Add(numFaults)
+
+	for idx := 0; idx < numFaults; idx++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			_, err := s.InjectFault(context.Background(), &pb.InjectFaultRequest{
+				BundleId:        fmt.Sprintf("app-%d", idx),
+				DelayMs:         100,
+				DelayPercent:    100,
+				DurationSeconds: 60,
+			})
+			assert.Check(t, err)
+		}(idx)
+	}
+
+	// like the xDS server, the receiver acquires the lock of the registry for every snapshot
+	var faults FaultSnapshot
+	for idx := 0; idx < numFaults; idx++ {
+		faults = <-chanFaults
+
+		mu.Lock()
+		time.Sleep(time.Millisecond)
+		mu.Unlock()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, len(faults), numFaults)
+}
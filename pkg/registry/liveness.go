@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"sync"
+	"time"
+)
+
+const (
+	// Interval between two checks of the heartbeat timeouts.
+	livenessCheckInterval = 1 * time.Second
+
+	// Maximum number of transitions that are kept per node.
+	maxLivenessTransitions = 32
+)
+
+// NodeHealth encodes the liveness state of a node.
+type NodeHealth string
+
+const (
+	// NodeHealthUnknown represents the state of a node that has not been tracked so far.
+	NodeHealthUnknown NodeHealth = ""
+	// NodeHealthHealthy represents the state of a node that recently sent a heartbeat.
+	NodeHealthHealthy NodeHealth = "healthy"
+	// NodeHealthSuspect represents the state of a node that missed heartbeats for longer than the suspect timeout.
+	NodeHealthSuspect NodeHealth = "suspect"
+	// NodeHealthDead represents the state of a node that missed heartbeats for longer than the dead timeout.
+	NodeHealthDead NodeHealth = "dead"
+)
+
+// HealthSnapshot represents a mapping of node IDs to their liveness state at one point in time.
+type HealthSnapshot map[string]NodeHealth
+
+// LivenessTransition encodes a change of the liveness state of a node.
+type LivenessTransition struct {
+	NodeID string
+	From   NodeHealth
+	To     NodeHealth
+	Time   time.Time
+}
+
+// NodeLiveness encodes the current liveness state of a node and its recent transitions.
+type NodeLiveness struct {
+	NodeID        string
+	Health        NodeHealth
+	LastHeartbeat time.Time
+	Transitions   []LivenessTransition
+}
+
+// LivenessTracker tracks the liveness of nodes based on their heartbeats.
+type LivenessTracker struct {
+	mu    sync.Mutex // protects nodes
+	nodes map[string]*NodeLiveness
+
+	suspectTimeout time.Duration
+	deadTimeout    time.Duration
+
+	publisher publisher[HealthSnapshot]
+}
+
+// NewLivenessTracker creates a new instance of LivenessTracker that publishes the health of all tracked nodes to the
+// provided channel whenever it changes.
+func NewLivenessTracker(suspectTimeout, deadTimeout time.Duration, chanHealth chan<- HealthSnapshot) *LivenessTracker {
+	return &LivenessTracker{
+		nodes:          make(map[string]*NodeLiveness),
+		suspectTimeout: suspectTimeout,
+		deadTimeout:    deadTimeout,
+		publisher:      publisher[HealthSnapshot]{ch: chanHealth},
+	}
+}
+
+// Heartbeat records a sign of life of the node with the provided ID and marks it as healthy.
+func (l *LivenessTracker) Heartbeat(nodeID string) {
+	now := time.Now()
+
+	l.mu.Lock()
+
+	node, ok := l.nodes[nodeID]
+	if !ok {
+		node = &NodeLiveness{NodeID: nodeID}
+		l.nodes[nodeID] = node
+	}
+
+	node.LastHeartbeat = now
+	transition, changed := l.transition(node, NodeHealthHealthy, now)
+
+	l.mu.Unlock()
+
+	if changed {
+		logTransition(transition)
+		l.publishHealth()
+	}
+}
+
+// Forget stops tracking the node with the provided ID, e.g., after it has been evicted.
+func (l *LivenessTracker) Forget(nodeID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.nodes, nodeID)
+}
+
+// Liveness returns the liveness of all tracked nodes.
+func (l *LivenessTracker) Liveness() []NodeLiveness {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	liveness := make([]NodeLiveness, 0, len(l.nodes))
+	for _, node := range l.nodes {
+		n := *node
+		n.Transitions = append([]LivenessTransition{}, node.Transitions...)
+
+		liveness = append(liveness, n)
+	}
+
+	return liveness
+}
+
+// Run blocks the caller and periodically checks the heartbeat timeouts of all tracked nodes.
+func (l *LivenessTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(livenessCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.check(now)
+		}
+	}
+}
+
+// check changes the health of all nodes whose heartbeats timed out at the provided time and publishes the health if any
+// node changed.
+func (l *LivenessTracker) check(now time.Time) []LivenessTransition {
+	transitions := l.checkTimeouts(now)
+	if len(transitions) == 0 {
+		return transitions
+	}
+
+	for _, t := range transitions {
+		logTransition(t)
+	}
+
+	l.publishHealth()
+
+	return transitions
+}
+
+func (l *LivenessTracker) checkTimeouts(now time.Time) []LivenessTransition {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	transitions := make([]LivenessTransition, 0)
+	for _, node := range l.nodes {
+		silence := now.Sub(node.LastHeartbeat)
+
+		health := NodeHealthHealthy
+		if silence > l.deadTimeout {
+			health = NodeHealthDead
+		} else if silence > l.suspectTimeout {
+			health = NodeHealthSuspect
+		}
+
+		if t, changed := l.transition(node, health, now); changed {
+			transitions = append(transitions, t)
+		}
+	}
+
+	return transitions
+}
+
+// transition changes the health of the provided node. The caller must hold the lock.
+func (l *LivenessTracker) transition(node *NodeLiveness, health NodeHealth, now time.Time) (LivenessTransition, bool) {
+	if node.Health == health {
+		return LivenessTransition{}, false
+	}
+
+	t := LivenessTransition{
+		NodeID: node.NodeID,
+		From:   node.Health,
+		To:     health,
+		Time:   now,
+	}
+
+	node.Health = health
+	node.Transitions = append(node.Transitions, t)
+	if len(node.Transitions) > maxLivenessTransitions {
+		node.Transitions = node.Transitions[len(node.Transitions)-maxLivenessTransitions:]
+	}
+
+	return t, true
+}
+
+// publishHealth sends the current health of all tracked nodes to the xDS server. The health is read at send time, so
+// that a transition computed by check cannot overtake a concurrent heartbeat. The caller must not hold the lock.
+func (l *LivenessTracker) publishHealth() {
+	l.publisher.publish(func() HealthSnapshot {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		health := make(HealthSnapshot, len(l.nodes))
+		for nodeID, node := range l.nodes {
+			health[nodeID] = node.Health
+		}
+
+		return health
+	})
+}
+
+func logTransition(t LivenessTransition) {
+	logging.DefaultLogger.Info().
+		Str("Node", t.NodeID).
+		Str("From", string(t.From)).
+		Str("To", string(t.To)).
+		Msg("Node liveness changed")
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"gotest.tools/v3/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLivenessTransitions(t *testing.T) {
+	chanHealth := make(chan HealthSnapshot, 10)
+	l := NewLivenessTracker(10*time.Second, 20*time.Second, chanHealth)
+
+	l.Heartbeat("node-a")
+	assert.DeepEqual(t, <-chanHealth, HealthSnapshot{"node-a": NodeHealthHealthy})
+
+	lastHeartbeat := l.Liveness()[0].LastHeartbeat
+
+	assert.Equal(t, len(l.check(lastHeartbeat.Add(5*time.Second))), 0)
+
+	transitions := l.check(lastHeartbeat.Add(15 * time.Second))
+	assert.Equal(t, len(transitions), 1)
+	assert.Equal(t, transitions[0].To, NodeHealthSuspect)
+	assert.DeepEqual(t, <-chanHealth, HealthSnapshot{"node-a": NodeHealthSuspect})
+
+	transitions = l.check(lastHeartbeat.Add(25 * time.Second))
+	assert.Equal(t, len(transitions), 1)
+	assert.Equal(t, transitions[0].From, NodeHealthSuspect)
+	assert.Equal(t, transitions[0].To, NodeHealthDead)
+	assert.DeepEqual(t, <-chanHealth, HealthSnapshot{"node-a": NodeHealthDead})
+
+	l.Heartbeat("node-a")
+	assert.DeepEqual(t, <-chanHealth, HealthSnapshot{"node-a": NodeHealthHealthy})
+
+	liveness := l.Liveness()
+	assert.Equal(t, len(liveness), 1)
+	assert.Equal(t, liveness[0].Health, NodeHealthHealthy)
+	assert.Equal(t, len(liveness[0].Transitions), 4)
+	assert.Equal(t, len(chanHealth), 0)
+}
+
+func TestLivenessPublishesInOrder(t *testing.T) {
+	const rounds = 1000
+
+	chanHealth := make(chan HealthSnapshot, 2*rounds)
+	l := NewLivenessTracker(10*time.Second, 20*time.Second, chanHealth)
+
+	l.Heartbeat("node-a")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// heartbeats revive the node that the checks declare dead in between
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < rounds; i++ {
+			l.Heartbeat("node-a")
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < rounds; i++ {
+			l.check(time.Now().Add(time.Minute))
+		}
+	}()
+
+	wg.Wait()
+	close(chanHealth)
+
+	var last HealthSnapshot
+	for health := range chanHealth {
+		last = health
+	}
+
+	// the most recently published health has to match the tracked health, regardless of how the calls interleaved
+	assert.DeepEqual(t, last, HealthSnapshot{"node-a": l.Liveness()[0].Health})
+}
+
+func TestLivenessForget(t *testing.T) {
+	l := NewLivenessTracker(10*time.Second, 20*time.Second, nil)
+
+	l.Heartbeat("node-a")
+	l.Forget("node-a")
+
+	assert.Equal(t, len(l.Liveness()), 0)
+}
@@ -11,20 +11,24 @@ import (
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
 	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/node/v1"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
 	"net"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 // NodeAddr encodes the hostname and port of an endpoint.
 type NodeAddr struct {
-	Host string
-	Port int
+	Host string `json:"host"`
+	Port int    `json:"port"`
 }
 
 // Network returns the address's network name, "tcp".
@@ -39,53 +43,181 @@ func (a *NodeAddr) String() string {
 	return net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
 }
 
-// GetNodeIdx extracts the node's index out of a node ID.
-func GetNodeIdx(nodeID string) (int, error) {
-	s := strings.Split(nodeID, "-")
+// Node encodes a registered node.
+type Node struct {
+	Hostname  string    `json:"hostname"`
+	Addr      *NodeAddr `json:"addr"`
+	Certified bool      `json:"certified,omitempty"` // whether the node has been issued a client certificate
+}
+
+// NodeSnapshot represents a mapping of node IDs to nodes at one point in time.
+type NodeSnapshot map[string]*Node
+
+// Clone creates a copy of the snapshot.
+func (s NodeSnapshot) Clone() NodeSnapshot {
+	c := make(NodeSnapshot, len(s))
+	for nodeID, node := range s {
+		c[nodeID] = &Node{
+			Hostname:  node.Hostname,
+			Addr:      &NodeAddr{Host: node.Addr.Host, Port: node.Addr.Port},
+			Certified: node.Certified,
+		}
+	}
+
+	return c
+}
+
+// NodeIDFromHostname derives the stable node ID from the hostname of a node. Hostnames that only differ in characters
+// other than lowercase letters, digits and dashes map to the same node ID.
+func NodeIDFromHostname(hostname string) string {
+	var sb strings.Builder
+
+	for _, r := range strings.ToLower(hostname) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('-')
+		}
+	}
 
-	return strconv.Atoi(s[len(s)-1])
+	return fmt.Sprintf("node-%s", sb.String())
 }
 
 // NodeRegistryServer implements the node registry server.
 type NodeRegistryServer struct {
 	pb.UnimplementedNodeRegistryServiceServer
 
-	mu    *sync.RWMutex // protects nodes
-	nodes []net.Addr
+	mu             *sync.RWMutex // protects nodes, evictionTimers and channels
+	nodes          NodeSnapshot
+	evictionTimers map[string]*time.Timer
+	channels       map[string]uint64 // generation of the most recently opened channel per node
 
 	broker *channel.Broker[*pb.DeploymentConfiguration]
 
-	chanNodes chan<- net.Addr
+	publisher publisher[NodeSnapshot]
+
+	store *Store
+
+	gracePeriod     time.Duration
+	evictionHandler func(nodeID string)
+
+	liveness *LivenessTracker
+
+	auth *Authenticator
 }
 
-// Register receives a RegisterRequest containing the IP address and port of the node and replies with assigned the node id after registration.
-func (s *NodeRegistryServer) Register(_ context.Context, addr *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+// Register receives a RegisterRequest containing the hostname, IP address and port of the node and replies with the node ID after registration. The node ID is
+// derived from the hostname, i.e., a node that registers again keeps its ID while its address and port are replaced.
+func (s *NodeRegistryServer) Register(ctx context.Context, addr *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	address := addr.Address
 	port := int(addr.Port)
 
+	hostname := addr.Hostname
+	if hostname == "" {
+		hostname = address
+	}
+
+	nodeID := NodeIDFromHostname(hostname)
+
+	// a node that presents a node certificate can only register under the identity of its certificate
+	certNodeID, hasCertificate := CertificateNodeID(ctx)
+	if hasCertificate && certNodeID != nodeID {
+		return nil, status.Error(codes.PermissionDenied, errorMsgNodeIDMismatch)
+	}
+
+	resp := &pb.RegisterResponse{Id: nodeID}
+
+	if s.auth != nil {
+		resp.Token = s.auth.NodeToken(nodeID)
+
+		cert, err := s.auth.NodeCertificate(nodeID, hostname)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if cert != nil {
+			resp.Certificate = cert.CertificatePEM
+			resp.PrivateKey = cert.PrivateKeyPEM
+		}
+	}
+
 	s.mu.Lock()
 
-	s.nodes = append(s.nodes, &NodeAddr{
-		Host: address,
-		Port: port,
-	})
+	// different hostnames may map to the same node ID, e.g., "hpc.1" and "hpc-1", but only one of them is registered
+	knownNode, isKnown := s.nodes[nodeID]
+	if isKnown && !strings.EqualFold(knownNode.Hostname, hostname) {
+		s.mu.Unlock()
+
+		return nil, status.Error(codes.AlreadyExists, errorMsgNodeIDTaken)
+	}
+
+	// The admission token is shared by all nodes, thus it only admits unknown nodes. A known node that has been issued a
+	// certificate has to present it, so that no other node can take over its ID and credentials.
+	if isKnown && knownNode.Certified && !hasCertificate {
+		s.mu.Unlock()
+
+		return nil, status.Error(codes.PermissionDenied, errorMsgCertificateRequired)
+	}
+
+	s.cancelEviction(nodeID)
+
+	s.nodes[nodeID] = &Node{
+		Hostname: hostname,
+		Addr: &NodeAddr{
+			Host: address,
+			Port: port,
+		},
+		Certified: len(resp.Certificate) > 0,
+	}
 
-	newNodeIdx := len(s.nodes) - 1
-	newNode := s.nodes[newNodeIdx]
+	err := s.store.SaveNodes(s.nodes)
 
 	s.mu.Unlock()
 
-	s.chanNodes <- newNode
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
-	logging.DefaultLogger.Debug().
-		Str("Node", fmt.Sprintf("node-%v", newNodeIdx)).
+	s.publishNodes()
+
+	s.liveness.Heartbeat(nodeID)
+
+	e := logging.DefaultLogger.Debug().
+		Str("Node", nodeID).
+		Str("Hostname", hostname).
 		Str("Address", address).
-		Int("Port", port).
-		Msg("Registering node")
+		Int("Port", port)
+
+	if isKnown {
+		e.Msg("Re-registering node")
+	} else {
+		e.Msg("Registering node")
+	}
+
+	return resp, nil
+}
+
+// Deregister removes the node with the provided ID from the registry and evicts its services.
+func (s *NodeRegistryServer) Deregister(ctx context.Context, req *pb.DeregisterRequest) (*emptypb.Empty, error) {
+	// a node can only deregister itself, i.e., the node ID header authenticated by the interceptor is mandatory
+	nodeID := firstHeaderValue(ctx, HeaderNodeID)
+	if nodeID == "" {
+		return nil, status.Error(codes.FailedPrecondition, errorMsgMissingHeader)
+	}
+
+	if nodeID != req.Id {
+		return nil, status.Error(codes.PermissionDenied, errorMsgNodeIDMismatch)
+	}
+
+	if _, err := s.ValidateNodeID(req.Id); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 
-	nodeID := fmt.Sprintf("node-%v", newNodeIdx)
+	if err := s.evict(req.Id); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
-	return &pb.RegisterResponse{Id: nodeID}, nil
+	return &emptypb.Empty{}, nil
 }
 
 // OpenChannel opens a gRPC channel that processes deployment configurations.
@@ -103,12 +235,29 @@ func (s *NodeRegistryServer) OpenChannel(stream pb.NodeRegistryService_OpenChann
 	}
 
 	// validate provided node ID
-	nodeIdx, err := s.ValidateNodeID(nodeID[0])
+	node, err := s.ValidateNodeID(nodeID[0])
 	if err != nil {
 		return status.Errorf(codes.FailedPrecondition, err.Error())
 	}
 
-	nodeHostname := s.nodes[nodeIdx].(*NodeAddr).Host
+	nodeHostname := node.Hostname
+
+	// A node that reopens its channel within the grace period is not evicted.
+	s.mu.Lock()
+	s.cancelEviction(nodeID[0])
+	s.channels[nodeID[0]]++
+	generation := s.channels[nodeID[0]]
+	s.mu.Unlock()
+
+	// A node that (re)connects, e.g., after the standby control plane took over, receives the most recent desired
+	// deployment configuration, even if the node that provides it is gone.
+	if desired := s.store.Desired(); len(desired) > 0 {
+		err := stream.Send(&pb.DeploymentConfiguration{
+			Json:      string(desired),
+			StateType: pb.DeploymentConfiguration_STATE_TYPE_DESIRED,
+		})
+		logging.LogErr(err)
+	}
 
 	// Processes all deployment configuration messages.
 	chRead := s.broker.Read()
@@ -131,7 +280,7 @@ func (s *NodeRegistryServer) OpenChannel(stream pb.NodeRegistryService_OpenChann
 
 	// Trigger initial distribution of deployment configuration for the node that opened the channel.
 	dplmCfg := config.DeploymentConfigForStartingNode(nodeHostname)
-	if j, err := dplmCfg.JSON(); err != nil {
+	if j, err := dplmCfg.JSON(); err == nil {
 		s.broker.Write(&pb.DeploymentConfiguration{
 			Json:      string(j),
 			StateType: pb.DeploymentConfiguration_STATE_TYPE_ACTUAL,
@@ -141,16 +290,19 @@ func (s *NodeRegistryServer) OpenChannel(stream pb.NodeRegistryService_OpenChann
 	for {
 		msgDplmCfg, err := stream.Recv()
 		if err != nil {
-			dplmCfg := config.DeploymentConfigForStoppedNode(nodeHostname)
-			if j, err := dplmCfg.JSON(); err != nil {
-				s.broker.Write(&pb.DeploymentConfiguration{
-					Json:      string(j),
-					StateType: pb.DeploymentConfiguration_STATE_TYPE_ACTUAL,
-				})
-			}
-
 			cancel()
 
+			// a node that already reopened its channel is neither stopped nor evicted
+			if s.closeChannel(nodeID[0], generation) {
+				dplmCfg := config.DeploymentConfigForStoppedNode(nodeHostname)
+				if j, err := dplmCfg.JSON(); err == nil {
+					s.broker.Write(&pb.DeploymentConfiguration{
+						Json:      string(j),
+						StateType: pb.DeploymentConfiguration_STATE_TYPE_ACTUAL,
+					})
+				}
+			}
+
 			if err == io.EOF {
 				return nil
 			} else {
@@ -158,34 +310,198 @@ func (s *NodeRegistryServer) OpenChannel(stream pb.NodeRegistryService_OpenChann
 			}
 		}
 
+		// every message, e.g., the periodically transmitted actual state, is a sign of life
+		s.liveness.Heartbeat(nodeID[0])
+
+		// the desired deployment configuration is replicated to the standby control plane as part of the store
+		if msgDplmCfg.StateType == pb.DeploymentConfiguration_STATE_TYPE_DESIRED {
+			err := s.store.SaveDesired([]byte(msgDplmCfg.Json))
+			logging.LogErr(err)
+		}
+
 		s.broker.Write(msgDplmCfg)
 	}
 }
 
-// ValidateNodeID checks whether the node with the provided ID has been registered.
-func (s *NodeRegistryServer) ValidateNodeID(nodeID string) (int, error) {
+// ListNodeLiveness replies with the liveness state and the recent liveness transitions of the registered nodes.
+func (s *NodeRegistryServer) ListNodeLiveness(_ context.Context, req *pb.ListNodeLivenessRequest) (*pb.ListNodeLivenessResponse, error) {
+	resp := &pb.ListNodeLivenessResponse{Nodes: make([]*pb.NodeLiveness, 0)}
+
+	for _, node := range s.liveness.Liveness() {
+		if req.Id != "" && req.Id != node.NodeID {
+			continue
+		}
+
+		transitions := make([]*pb.LivenessTransition, len(node.Transitions))
+		for idx, t := range node.Transitions {
+			transitions[idx] = &pb.LivenessTransition{
+				From: toProtoLivenessState(t.From),
+				To:   toProtoLivenessState(t.To),
+				Time: timestamppb.New(t.Time),
+			}
+		}
+
+		resp.Nodes = append(resp.Nodes, &pb.NodeLiveness{
+			Id:            node.NodeID,
+			State:         toProtoLivenessState(node.Health),
+			LastHeartbeat: timestamppb.New(node.LastHeartbeat),
+			Transitions:   transitions,
+		})
+	}
+
+	slices.SortFunc(resp.Nodes, func(a, b *pb.NodeLiveness) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return resp, nil
+}
+
+func toProtoLivenessState(health NodeHealth) pb.LivenessState {
+	switch health {
+	case NodeHealthHealthy:
+		return pb.LivenessState_LIVENESS_STATE_HEALTHY
+	case NodeHealthSuspect:
+		return pb.LivenessState_LIVENESS_STATE_SUSPECT
+	case NodeHealthDead:
+		return pb.LivenessState_LIVENESS_STATE_DEAD
+	default:
+		return pb.LivenessState_LIVENESS_STATE_UNSPECIFIED
+	}
+}
+
+// ValidateNodeID checks whether the node with the provided ID has been registered and returns it.
+func (s *NodeRegistryServer) ValidateNodeID(nodeID string) (*Node, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	nodeIdx, err := GetNodeIdx(nodeID)
+	node, ok := s.nodes[nodeID]
+	if !ok {
+		return nil, errors.New(errorInvalidNodeID)
+	}
+
+	return node, nil
+}
+
+// UseAuthenticator sets the authenticator that issues the credentials of registering nodes.
+func (s *NodeRegistryServer) UseAuthenticator(auth *Authenticator) {
+	s.auth = auth
+}
+
+// HandleEviction sets the handler that is invoked after a node has been evicted from the registry.
+func (s *NodeRegistryServer) HandleEviction(handler func(nodeID string)) {
+	s.evictionHandler = handler
+}
+
+// closeChannel schedules the eviction of the node with the provided ID after its channel of the provided generation
+// closed, unless the node opened another channel in the meantime. It reports whether the eviction has been scheduled.
+// The caller must not hold the lock.
+func (s *NodeRegistryServer) closeChannel(nodeID string, generation uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.channels[nodeID] != generation {
+		logging.DefaultLogger.Debug().
+			Str("Node", nodeID).
+			Msg("Ignoring closed channel superseded by a newer one")
+
+		return false
+	}
+
+	s.scheduleEviction(nodeID)
+
+	return true
+}
+
+// scheduleEviction evicts the node with the provided ID once the grace period expired. The caller must hold the lock.
+func (s *NodeRegistryServer) scheduleEviction(nodeID string) {
+	s.cancelEviction(nodeID)
+
+	logging.DefaultLogger.Debug().
+		Str("Node", nodeID).
+		Dur("GracePeriod", s.gracePeriod).
+		Msg("Scheduling eviction of node")
+
+	s.evictionTimers[nodeID] = time.AfterFunc(s.gracePeriod, func() {
+		err := s.evict(nodeID)
+		logging.LogErr(err)
+	})
+}
+
+// cancelEviction cancels a pending eviction of the node with the provided ID. The caller must hold the lock.
+func (s *NodeRegistryServer) cancelEviction(nodeID string) {
+	if t, ok := s.evictionTimers[nodeID]; ok {
+		t.Stop()
+
+		delete(s.evictionTimers, nodeID)
+	}
+}
+
+// evict removes the node with the provided ID from the registry and invokes the eviction handler.
+func (s *NodeRegistryServer) evict(nodeID string) error {
+	s.mu.Lock()
+
+	s.cancelEviction(nodeID)
+
+	if _, ok := s.nodes[nodeID]; !ok {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	delete(s.nodes, nodeID)
+	s.liveness.Forget(nodeID)
+
+	err := s.store.SaveNodes(s.nodes)
+
+	s.mu.Unlock()
+
 	if err != nil {
-		return -1, err
+		return err
 	}
 
-	if nodeIdx < 0 || nodeIdx > len(s.nodes)-1 {
-		return -1, errors.New(errorInvalidNodeID)
+	logging.DefaultLogger.Info().
+		Str("Node", nodeID).
+		Msg("Evicted node")
+
+	// evict the services first, so that no snapshot refers to a node that is gone
+	if s.evictionHandler != nil {
+		s.evictionHandler(nodeID)
 	}
 
-	return nodeIdx, nil
+	s.publishNodes()
+
+	return nil
 }
 
-// NewNodeRegistryServer creates a new instance of the NodeRegistryServer.
-func NewNodeRegistryServer(mu *sync.RWMutex, channelNodes chan<- net.Addr) *NodeRegistryServer {
+// publishNodes sends the current nodes to the xDS server. The caller must not hold the lock, as the receiver acquires it.
+func (s *NodeRegistryServer) publishNodes() {
+	s.publisher.publish(func() NodeSnapshot {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.nodes.Clone()
+	})
+}
+
+// NewNodeRegistryServer creates a new instance of the NodeRegistryServer and restores the nodes persisted in the store. Nodes whose channel closes are
+// evicted after the provided grace period unless they reconnect in time. The liveness of the nodes is tracked by the provided LivenessTracker.
+func NewNodeRegistryServer(mu *sync.RWMutex, channelNodes chan<- NodeSnapshot, store *Store, gracePeriod time.Duration,
+	liveness *LivenessTracker) *NodeRegistryServer {
 	s := &NodeRegistryServer{
-		mu:        mu,
-		nodes:     make([]net.Addr, 0),
-		broker:    channel.NewBroker[*pb.DeploymentConfiguration](),
-		chanNodes: channelNodes,
+		mu:             mu,
+		nodes:          store.Nodes(),
+		evictionTimers: make(map[string]*time.Timer),
+		channels:       make(map[string]uint64),
+		broker:         channel.NewBroker[*pb.DeploymentConfiguration](),
+		publisher:      publisher[NodeSnapshot]{ch: channelNodes},
+		store:          store,
+		gracePeriod:    gracePeriod,
+		liveness:       liveness,
+	}
+
+	// Restored nodes are considered healthy until their heartbeats time out.
+	for nodeID := range s.nodes {
+		liveness.Heartbeat(nodeID)
 	}
 
 	go s.broker.Listen()
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/node/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestNodeRegistryServer(t *testing.T) (*NodeRegistryServer, chan NodeSnapshot) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	chanNodes := make(chan NodeSnapshot, 10)
+
+	return NewNodeRegistryServer(&sync.RWMutex{}, chanNodes, store, 10*time.Millisecond, NewLivenessTracker(time.Second, 2*time.Second, nil)), chanNodes
+}
+
+func TestNodeIDFromHostname(t *testing.T) {
+	assert.Equal(t, NodeIDFromHostname("carisma-central"), "node-carisma-central")
+	assert.Equal(t, NodeIDFromHostname("HPC_1.vehicle"), "node-hpc-1-vehicle")
+}
+
+func TestRegisterTwice(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	r1, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "10.0.0.1", Port: 8000, Hostname: "hpc-1"})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	r2, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "10.0.0.2", Port: 8200, Hostname: "hpc-1"})
+	assert.NilError(t, err)
+	nodes := <-chanNodes
+
+	assert.Equal(t, r1.Id, r2.Id)
+	assert.Equal(t, len(nodes), 1)
+	assert.DeepEqual(t, nodes[r2.Id].Addr, &NodeAddr{Host: "10.0.0.2", Port: 8200})
+}
+
+func TestValidateNodeID(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	r, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "hpc-1", Port: 8000})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	node, err := s.ValidateNodeID(r.Id)
+	assert.NilError(t, err)
+	assert.Equal(t, node.Hostname, "hpc-1")
+
+	_, err = s.ValidateNodeID("node-0")
+	assert.ErrorContains(t, err, errorInvalidNodeID)
+}
+
+func TestDeregister(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	r, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "hpc-1", Port: 8000})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	var evictedNodeID string
+	s.HandleEviction(func(nodeID string) {
+		evictedNodeID = nodeID
+	})
+
+	// a node can only deregister itself
+	_, err = s.Deregister(context.Background(), &pb.DeregisterRequest{Id: r.Id})
+	assert.Equal(t, status.Code(err), codes.FailedPrecondition)
+
+	_, err = s.Deregister(metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderNodeID, "node-hpc-2")),
+		&pb.DeregisterRequest{Id: r.Id})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(HeaderNodeID, r.Id))
+
+	_, err = s.Deregister(ctx, &pb.DeregisterRequest{Id: r.Id})
+	assert.NilError(t, err)
+
+	nodes := <-chanNodes
+	assert.Equal(t, len(nodes), 0)
+	assert.Equal(t, evictedNodeID, r.Id)
+
+	_, err = s.Deregister(ctx, &pb.DeregisterRequest{Id: r.Id})
+	assert.ErrorContains(t, err, errorInvalidNodeID)
+}
+
+func TestEvictionAfterGracePeriod(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	r, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "hpc-1", Port: 8000})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	assert.Check(t, s.closeChannel(r.Id, 0))
+
+	nodes := <-chanNodes
+	assert.Equal(t, len(nodes), 0)
+}
+
+func TestEvictionCancelledByRegistration(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+	s.gracePeriod = 50 * time.Millisecond
+
+	r, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "hpc-1", Port: 8000})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	assert.Check(t, s.closeChannel(r.Id, 0))
+
+	_, err = s.Register(context.Background(), &pb.RegisterRequest{Address: "hpc-1", Port: 8000})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	select {
+	case <-chanNodes:
+		t.Fatal("node has been evicted although it registered again")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = s.ValidateNodeID(r.Id)
+	assert.NilError(t, err)
+}
+
+func TestSupersededChannelDoesNotEvict(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	r, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "hpc-1", Port: 8000})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	// the node reopened its channel before the previous one reported its error
+	s.mu.Lock()
+	s.channels[r.Id] = 2
+	s.mu.Unlock()
+
+	assert.Check(t, !s.closeChannel(r.Id, 1))
+
+	select {
+	case <-chanNodes:
+		t.Fatal("node has been evicted although its current channel is open")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.Check(t, s.closeChannel(r.Id, 2))
+
+	nodes := <-chanNodes
+	assert.Equal(t, len(nodes), 0)
+}
+
+func TestConcurrentRegistrationsPublishLatestNodes(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	const numNodes = 20
+
+	var wg sync.WaitGroup
+	wg.Add(numNodes)
+
+	for idx := 0; idx < numNodes; idx++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			_, err := s.Register(context.Background(), &pb.RegisterRequest{
+				Address:  "10.0.0.1",
+				Port:     8000,
+				Hostname: fmt.Sprintf("hpc-%d", idx),
+			})
+			assert.Check(t, err)
+		}(idx)
+	}
+
+	// like the xDS server, the receiver acquires the lock of the registry for every snapshot
+	var nodes NodeSnapshot
+	for idx := 0; idx < numNodes; idx++ {
+		nodes = <-chanNodes
+
+		s.mu.Lock()
+		time.Sleep(time.Millisecond)
+		s.mu.Unlock()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, len(nodes), numNodes)
+}
+
+func TestRegisterRejectsNodeIDCollision(t *testing.T) {
+	s, chanNodes := newTestNodeRegistryServer(t)
+
+	_, err := s.Register(context.Background(), &pb.RegisterRequest{Address: "10.0.0.1", Port: 8000, Hostname: "hpc-1"})
+	assert.NilError(t, err)
+	<-chanNodes
+
+	_, err = s.Register(context.Background(), &pb.RegisterRequest{Address: "10.0.0.2", Port: 8000, Hostname: "hpc.1"})
+	assert.Equal(t, status.Code(err), codes.AlreadyExists)
+
+	node, err := s.ValidateNodeID(NodeIDFromHostname("hpc-1"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, node.Addr, &NodeAddr{Host: "10.0.0.1", Port: 8000})
+}
@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+var lbPolicies = map[config.LBPolicy]pb.TrafficPolicy_LBPolicy{
+	"":                          pb.TrafficPolicy_LB_POLICY_UNSPECIFIED,
+	config.LBPolicyRoundRobin:   pb.TrafficPolicy_LB_POLICY_ROUND_ROBIN,
+	config.LBPolicyLeastRequest: pb.TrafficPolicy_LB_POLICY_LEAST_REQUEST,
+	config.LBPolicyRandom:       pb.TrafficPolicy_LB_POLICY_RANDOM,
+}
+
+// TrafficPolicyToProto converts a traffic policy into its protobuf representation.
+func TrafficPolicyToProto(p *config.TrafficPolicy) *pb.TrafficPolicy {
+	if p == nil {
+		return nil
+	}
+
+	m := &pb.TrafficPolicy{
+		TimeoutMs:        p.TimeoutMs,
+		ConnectTimeoutMs: p.ConnectTimeoutMs,
+		LbPolicy:         lbPolicies[p.LBPolicy],
+	}
+
+	if p.Retry != nil {
+		m.Retry = &pb.RetryPolicy{
+			RetryOn:         slices.Clone(p.Retry.RetryOn),
+			NumRetries:      p.Retry.NumRetries,
+			PerTryTimeoutMs: p.Retry.PerTryTimeoutMs,
+		}
+	}
+
+	if p.CircuitBreaker != nil {
+		m.CircuitBreaker = &pb.CircuitBreaker{
+			MaxConnections:     p.CircuitBreaker.MaxConnections,
+			MaxPendingRequests: p.CircuitBreaker.MaxPendingRequests,
+			MaxRequests:        p.CircuitBreaker.MaxRequests,
+			MaxRetries:         p.CircuitBreaker.MaxRetries,
+		}
+	}
+
+	if p.HealthCheck != nil {
+		m.HealthCheck = &pb.HealthCheck{
+			IntervalMs:         p.HealthCheck.IntervalMs,
+			TimeoutMs:          p.HealthCheck.TimeoutMs,
+			UnhealthyThreshold: p.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   p.HealthCheck.HealthyThreshold,
+			Path:               p.HealthCheck.Path,
+			ServiceName:        p.HealthCheck.ServiceName,
+		}
+	}
+
+	if p.OutlierDetection != nil {
+		m.OutlierDetection = &pb.OutlierDetection{
+			Consecutive_5Xx:           p.OutlierDetection.Consecutive5xx,
+			ConsecutiveGatewayFailure: p.OutlierDetection.ConsecutiveGatewayFailure,
+			IntervalMs:                p.OutlierDetection.IntervalMs,
+			BaseEjectionTimeMs:        p.OutlierDetection.BaseEjectionTimeMs,
+			MaxEjectionPercent:        p.OutlierDetection.MaxEjectionPercent,
+		}
+	}
+
+	if p.RateLimit != nil {
+		m.RateLimit = &pb.RateLimit{
+			RequestsPerSecond: p.RateLimit.RequestsPerSecond,
+			PerCaller:         maps.Clone(p.RateLimit.PerCaller),
+			Global:            p.RateLimit.Global,
+		}
+	}
+
+	return m
+}
+
+// TrafficPolicyFromProto converts the protobuf representation of a traffic policy into a traffic policy.
+func TrafficPolicyFromProto(m *pb.TrafficPolicy) *config.TrafficPolicy {
+	if m == nil {
+		return nil
+	}
+
+	p := &config.TrafficPolicy{
+		TimeoutMs:        m.TimeoutMs,
+		ConnectTimeoutMs: m.ConnectTimeoutMs,
+	}
+
+	for lbPolicy, pbLBPolicy := range lbPolicies {
+		if pbLBPolicy == m.LbPolicy {
+			p.LBPolicy = lbPolicy
+		}
+	}
+
+	if m.Retry != nil {
+		p.Retry = &config.RetryPolicy{
+			RetryOn:         slices.Clone(m.Retry.RetryOn),
+			NumRetries:      m.Retry.NumRetries,
+			PerTryTimeoutMs: m.Retry.PerTryTimeoutMs,
+		}
+	}
+
+	if m.CircuitBreaker != nil {
+		p.CircuitBreaker = &config.CircuitBreaker{
+			MaxConnections:     m.CircuitBreaker.MaxConnections,
+			MaxPendingRequests: m.CircuitBreaker.MaxPendingRequests,
+			MaxRequests:        m.CircuitBreaker.MaxRequests,
+			MaxRetries:         m.CircuitBreaker.MaxRetries,
+		}
+	}
+
+	if m.HealthCheck != nil {
+		p.HealthCheck = &config.HealthCheck{
+			IntervalMs:         m.HealthCheck.IntervalMs,
+			TimeoutMs:          m.HealthCheck.TimeoutMs,
+			UnhealthyThreshold: m.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   m.HealthCheck.HealthyThreshold,
+			Path:               m.HealthCheck.Path,
+			ServiceName:        m.HealthCheck.ServiceName,
+		}
+	}
+
+	if m.OutlierDetection != nil {
+		p.OutlierDetection = &config.OutlierDetection{
+			Consecutive5xx:            m.OutlierDetection.Consecutive_5Xx,
+			ConsecutiveGatewayFailure: m.OutlierDetection.ConsecutiveGatewayFailure,
+			IntervalMs:                m.OutlierDetection.IntervalMs,
+			BaseEjectionTimeMs:        m.OutlierDetection.BaseEjectionTimeMs,
+			MaxEjectionPercent:        m.OutlierDetection.MaxEjectionPercent,
+		}
+	}
+
+	if m.RateLimit != nil {
+		p.RateLimit = &config.RateLimit{
+			RequestsPerSecond: m.RateLimit.RequestsPerSecond,
+			PerCaller:         maps.Clone(m.RateLimit.PerCaller),
+			Global:            m.RateLimit.Global,
+		}
+	}
+
+	return p
+}
+
+var protocols = map[config.Protocol]pb.Protocol{
+	"":                   pb.Protocol_PROTOCOL_UNSPECIFIED,
+	config.ProtocolGRPC:  pb.Protocol_PROTOCOL_GRPC,
+	config.ProtocolHTTP:  pb.Protocol_PROTOCOL_HTTP,
+	config.ProtocolHTTP2: pb.Protocol_PROTOCOL_HTTP2,
+}
+
+// ProtocolToProto converts a protocol into its protobuf representation.
+func ProtocolToProto(p config.Protocol) pb.Protocol {
+	return protocols[p]
+}
+
+// ProtocolFromProto converts the protobuf representation of a protocol into a protocol.
+func ProtocolFromProto(m pb.Protocol) config.Protocol {
+	for protocol, pbProtocol := range protocols {
+		if pbProtocol == m {
+			return protocol
+		}
+	}
+
+	return ""
+}
+
+var criticalities = map[config.Criticality]pb.Criticality{
+	"":                      pb.Criticality_CRITICALITY_UNSPECIFIED,
+	config.CriticalityQM:    pb.Criticality_CRITICALITY_QM,
+	config.CriticalityASILA: pb.Criticality_CRITICALITY_ASIL_A,
+	config.CriticalityASILB: pb.Criticality_CRITICALITY_ASIL_B,
+	config.CriticalityASILC: pb.Criticality_CRITICALITY_ASIL_C,
+	config.CriticalityASILD: pb.Criticality_CRITICALITY_ASIL_D,
+}
+
+// CriticalityToProto converts a criticality class into its protobuf representation.
+func CriticalityToProto(c config.Criticality) pb.Criticality {
+	return criticalities[c]
+}
+
+// CriticalityFromProto converts the protobuf representation of a criticality class into a criticality class.
+func CriticalityFromProto(m pb.Criticality) config.Criticality {
+	for criticality, pbCriticality := range criticalities {
+		if pbCriticality == m {
+			return criticality
+		}
+	}
+
+	return ""
+}
+
+// HTTPRoutesToProto converts HTTP routes into their protobuf representation.
+func HTTPRoutesToProto(routes []config.HTTPRoute) []*pb.HTTPRoute {
+	m := make([]*pb.HTTPRoute, len(routes))
+	for idx, r := range routes {
+		m[idx] = &pb.HTTPRoute{
+			Prefix:  r.Prefix,
+			Headers: maps.Clone(r.Headers),
+		}
+	}
+
+	return m
+}
+
+// HTTPRoutesFromProto converts the protobuf representation of HTTP routes into HTTP routes.
+func HTTPRoutesFromProto(m []*pb.HTTPRoute) []config.HTTPRoute {
+	if len(m) == 0 {
+		return nil
+	}
+
+	routes := make([]config.HTTPRoute, len(m))
+	for idx, r := range m {
+		routes[idx] = config.HTTPRoute{
+			Prefix:  r.Prefix,
+			Headers: maps.Clone(r.Headers),
+		}
+	}
+
+	return routes
+}
+
+var transportProtocols = map[config.TransportProtocol]pb.TransportProtocol{
+	"":                          pb.TransportProtocol_TRANSPORT_PROTOCOL_UNSPECIFIED,
+	config.TransportProtocolTCP: pb.TransportProtocol_TRANSPORT_PROTOCOL_TCP,
+	config.TransportProtocolUDP: pb.TransportProtocol_TRANSPORT_PROTOCOL_UDP,
+}
+
+// ServicePortsToProto converts service ports together with the local ports of an instance, keyed by the name of the
+// service port, into their protobuf representation.
+func ServicePortsToProto(ports []config.ServicePort, localPorts map[string]int32) []*pb.ServicePort {
+	m := make([]*pb.ServicePort, len(ports))
+	for idx, p := range ports {
+		m[idx] = &pb.ServicePort{
+			Name:       p.Name,
+			Protocol:   transportProtocols[p.Protocol],
+			Port:       p.Port,
+			TargetPort: p.TargetPort,
+			LocalPort:  localPorts[p.Name],
+		}
+	}
+
+	return m
+}
+
+// ServicePortsFromProto converts the protobuf representation of service ports into service ports and the local ports of
+// the announced instance keyed by the name of the service port.
+func ServicePortsFromProto(m []*pb.ServicePort) ([]config.ServicePort, map[string]int32) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	ports := make([]config.ServicePort, len(m))
+	localPorts := make(map[string]int32, len(m))
+	for idx, p := range m {
+		ports[idx] = config.ServicePort{
+			Name:       p.Name,
+			Port:       p.Port,
+			TargetPort: p.TargetPort,
+		}
+
+		for protocol, pbProtocol := range transportProtocols {
+			if pbProtocol == p.Protocol {
+				ports[idx].Protocol = protocol
+			}
+		}
+
+		if p.LocalPort > 0 {
+			localPorts[p.Name] = p.LocalPort
+		}
+	}
+
+	return ports, localPorts
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"sync"
+)
+
+// publisher sends snapshots of a registry to the xDS server in the order of the changes they reflect.
+type publisher[T any] struct {
+	mu sync.Mutex // orders the snapshots sent on ch
+	ch chan<- T
+}
+
+// publish sends the snapshot taken by the provided function. The snapshot is only taken once the previous snapshot has
+// been sent, so that concurrent changes cannot overtake each other and a stale snapshot never replaces a more recent
+// one. The caller must not hold the lock of the registry, as the receiver acquires it.
+func (p *publisher[T]) publish(snapshot func() T) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := snapshot()
+	if p.ch != nil {
+		p.ch <- s
+	}
+}
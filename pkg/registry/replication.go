@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/replication/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const (
+	// Delay between two attempts to reach the leading control plane.
+	replicationRetryDelay = 1 * time.Second
+)
+
+// ReplicationServer implements the replication server that streams the persisted state of the registry of the leading
+// control plane to the standby control plane.
+type ReplicationServer struct {
+	pb.UnimplementedReplicationServiceServer
+
+	store    *Store
+	interval time.Duration
+}
+
+// Replicate streams the persisted state whenever it changes, but at least once per interval, so that the standby control
+// plane recognizes a failed leader even if the connection is not closed.
+func (r *ReplicationServer) Replicate(req *pb.ReplicateRequest, stream pb.ReplicationService_ReplicateServer) error {
+	logging.DefaultLogger.Info().
+		Str("Standby", req.Hostname).
+		Msg("Replicating registry to standby control plane")
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		changed := r.store.Changed()
+
+		state, err := r.store.State()
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if err := stream.Send(&pb.ReplicatedState{Term: r.store.Term(), State: state}); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewReplicationServer creates a new instance of ReplicationServer that sends the state at least once per provided
+// interval.
+func NewReplicationServer(store *Store, interval time.Duration) *ReplicationServer {
+	return &ReplicationServer{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Follower replicates the persisted state of the registry of the leading control plane into the local store.
+type Follower struct {
+	client   pb.ReplicationServiceClient
+	store    *Store
+	hostname string
+	timeout  time.Duration
+}
+
+// follow receives the state of the leading control plane until the stream breaks or no state was received within the
+// timeout since the provided time of the last state.
+func (f *Follower) follow(ctx context.Context, lastReceived *time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := f.client.Replicate(ctx, &pb.ReplicateRequest{Hostname: f.hostname})
+	if err != nil {
+		return err
+	}
+
+	// a leader that stops sending has failed, even if the connection is still open
+	timer := time.AfterFunc(f.timeout-time.Since(*lastReceived), cancel)
+	defer timer.Stop()
+
+	for {
+		replicated, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if err := f.store.ReplaceState(replicated.State); err != nil {
+			return err
+		}
+
+		*lastReceived = time.Now()
+		timer.Reset(f.timeout)
+
+		logging.DefaultLogger.Debug().
+			Uint64("Term", replicated.Term).
+			Msg("Replicated registry of leading control plane")
+	}
+}
+
+// Follow replicates the state of the leading control plane until the leader failed, i.e., until no state was received
+// within the timeout. If the provided probe duration is positive, Follow also returns if the leader could not be reached
+// at all within it, e.g., if a control plane starts while its peer is not leading. Follow only returns an error if the
+// provided context is done.
+func (f *Follower) Follow(ctx context.Context, probe time.Duration) error {
+	lastReceived := time.Now()
+	start := lastReceived
+
+	for {
+		err := f.follow(ctx, &lastReceived)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if probe > 0 && lastReceived.Equal(start) && time.Since(start) >= probe {
+			return nil
+		}
+
+		if time.Since(lastReceived) >= f.timeout {
+			logging.DefaultLogger.Warn().
+				Err(err).
+				Time("LastReplication", lastReceived).
+				Msg("Leading control plane failed")
+
+			return nil
+		}
+
+		logging.DefaultLogger.Debug().Err(err).Msg("Could not replicate registry of leading control plane")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(replicationRetryDelay):
+		}
+	}
+}
+
+// probe returns the term of the peer control plane if it leads, i.e., if it serves the replication of its registry.
+func (f *Follower) probe(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	stream, err := f.client.Replicate(ctx, &pb.ReplicateRequest{Hostname: f.hostname})
+	if err != nil {
+		return 0, err
+	}
+
+	replicated, err := stream.Recv()
+	if err != nil {
+		return 0, err
+	}
+
+	return replicated.Term, nil
+}
+
+// AwaitSuperseded probes the peer control plane once per provided interval and returns its term once it leads with a
+// higher term than the provided one, e.g., as it took over while the control plane of the store was partitioned from
+// it. AwaitSuperseded only returns an error if the provided context is done.
+func (f *Follower) AwaitSuperseded(ctx context.Context, term uint64, interval time.Duration) (uint64, error) {
+	for {
+		peerTerm, err := f.probe(ctx)
+		if err == nil && peerTerm > term {
+			return peerTerm, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// NewFollower creates a new instance of Follower that considers the leading control plane failed if no state was
+// received within the provided timeout.
+func NewFollower(client pb.ReplicationServiceClient, store *Store, hostname string, timeout time.Duration) *Follower {
+	return &Follower{
+		client:   client,
+		store:    store,
+		hostname: hostname,
+		timeout:  timeout,
+	}
+}
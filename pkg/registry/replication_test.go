@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/replication/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/v3/assert"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreReplaceState(t *testing.T) {
+	leader, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, leader.SaveNodes(NodeSnapshot{"node-host-1": {Hostname: "host-1", Addr: &NodeAddr{Host: "host-1", Port: 8000}}}))
+	assert.NilError(t, leader.SaveDesired([]byte(`{"host-1":{"images":["app:v1"]}}`)))
+	assert.NilError(t, leader.SaveTerm(3))
+	assert.ErrorContains(t, leader.SaveDesired([]byte("{")), "invalid")
+
+	state, err := leader.State()
+	assert.NilError(t, err)
+
+	filePath := filepath.Join(t.TempDir(), "registry.json")
+	standby, err := NewStore(filePath)
+	assert.NilError(t, err)
+
+	changed := standby.Changed()
+	assert.NilError(t, standby.ReplaceState(state))
+
+	select {
+	case <-changed:
+	default:
+		t.Fatal("change of the state was not announced")
+	}
+
+	restored, err := NewStore(filePath)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, restored.Nodes(), leader.Nodes())
+	assert.Equal(t, len(restored.Services()), 0)
+	var desired bytes.Buffer
+	assert.NilError(t, json.Compact(&desired, restored.Desired()))
+	assert.Equal(t, desired.String(), `{"host-1":{"images":["app:v1"]}}`)
+	assert.Equal(t, restored.Term(), uint64(3))
+}
+
+func TestFollowUntilLeaderFails(t *testing.T) {
+	leader, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, leader.SaveTerm(1))
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterReplicationServiceServer(server, NewReplicationServer(leader, 10*time.Millisecond))
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///leader",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	standby, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- NewFollower(pb.NewReplicationServiceClient(conn), standby, "host-2", 200*time.Millisecond).
+			Follow(context.Background(), 0)
+	}()
+
+	// changes of the leader are replicated right away
+	changed := standby.Changed()
+	assert.NilError(t, leader.SaveNodes(NodeSnapshot{"node-host-1": {Hostname: "host-1", Addr: &NodeAddr{Host: "host-1", Port: 8000}}}))
+
+	for len(standby.Nodes()) == 0 {
+		select {
+		case <-changed:
+			changed = standby.Changed()
+		case <-time.After(time.Second):
+			t.Fatal("state was not replicated")
+		}
+	}
+
+	server.Stop()
+
+	select {
+	case err := <-done:
+		assert.NilError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("failed leader was not detected")
+	}
+
+	assert.DeepEqual(t, standby.Nodes(), leader.Nodes())
+	assert.Equal(t, standby.Term(), uint64(1))
+}
+
+func TestFollowProbe(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	assert.NilError(t, lis.Close())
+
+	conn, err := grpc.NewClient("passthrough:///leader",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	// the peer is probed for at least the provided duration, e.g., if both control planes start at the same time
+	start := time.Now()
+
+	err = NewFollower(pb.NewReplicationServiceClient(conn), store, "host-1", time.Minute).
+		Follow(context.Background(), 100*time.Millisecond)
+	assert.NilError(t, err)
+	assert.Assert(t, time.Since(start) >= 100*time.Millisecond)
+}
+
+func TestAwaitSuperseded(t *testing.T) {
+	peer, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, peer.SaveTerm(2))
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterReplicationServiceServer(server, NewReplicationServer(peer, 10*time.Millisecond))
+
+	conn, err := grpc.NewClient("passthrough:///peer",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	follower := NewFollower(pb.NewReplicationServiceClient(conn), store, "host-1", 100*time.Millisecond)
+
+	done := make(chan uint64)
+	go func() {
+		term, err := follower.AwaitSuperseded(context.Background(), 1, 10*time.Millisecond)
+		assert.Check(t, err)
+		done <- term
+	}()
+
+	// a peer that does not lead does not supersede the leader
+	select {
+	case <-done:
+		t.Fatal("leader was superseded by a peer that does not lead")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	select {
+	case term := <-done:
+		assert.Equal(t, term, uint64(2))
+	case <-time.After(2 * time.Second):
+		t.Fatal("peer with higher term was not detected")
+	}
+
+	// a peer with the same term does not supersede the leader
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = follower.AwaitSuperseded(ctx, 2, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -4,8 +4,11 @@
 package registry
 
 import (
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
 	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
 	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -19,8 +22,151 @@ const (
 	HeaderNodeID = "x-carisma-node-id"
 )
 
+// ServiceConfig encodes the local ports of all instances of a bundle on a node grouped by bundle version and the
+// properties declared by the bundle. Declared properties are never modified once announced, but only replaced as a whole.
+// The local ports of the TCP and UDP service ports of the instances are grouped by the name of the service port first.
+// The local ports of the instances that Envoy considers unhealthy are additionally grouped by bundle version. The caller
+// tokens identify the instances as callers at the egress listeners of Envoy on the node.
+type ServiceConfig struct {
+	Versions     map[string][]int32            `json:"versions"`
+	Policy       *config.TrafficPolicy         `json:"policy,omitempty"`
+	Protocol     config.Protocol               `json:"protocol,omitempty"`
+	Routes       []config.HTTPRoute            `json:"routes,omitempty"`
+	Ports        []config.ServicePort          `json:"ports,omitempty"`
+	Criticality  config.Criticality            `json:"criticality,omitempty"`
+	PortVersions map[string]map[string][]int32 `json:"port_versions,omitempty"`
+	Unhealthy    map[string][]int32            `json:"unhealthy,omitempty"`
+	CallerTokens []string                      `json:"caller_tokens,omitempty"`
+}
+
+// Healthy checks whether at least one instance of the provided version was not reported unhealthy.
+func (s *ServiceConfig) Healthy(version string) bool {
+	return len(s.Versions[version]) > len(s.Unhealthy[version])
+}
+
+// declaration encodes the properties of a service that its bundle declares as a whole.
+type declaration struct {
+	policy      *config.TrafficPolicy
+	protocol    config.Protocol
+	routes      []config.HTTPRoute
+	ports       []config.ServicePort
+	criticality config.Criticality
+}
+
+// declarationFromAnnouncement extracts the declared properties from the provided announcement. Invalid properties are
+// ignored, as they must not prevent the service from being reachable.
+func declarationFromAnnouncement(nodeID string, announcement *pb.ServiceAnnouncement) declaration {
+	d := declaration{
+		policy:      TrafficPolicyFromProto(announcement.TrafficPolicy),
+		protocol:    ProtocolFromProto(announcement.Protocol),
+		routes:      HTTPRoutesFromProto(announcement.Routes),
+		criticality: CriticalityFromProto(announcement.Criticality),
+	}
+	d.ports, _ = ServicePortsFromProto(announcement.Ports)
+
+	if err := d.policy.Validate(); err != nil {
+		logging.DefaultLogger.Error().Err(err).
+			Str("Node-ID", nodeID).
+			Str("Bundle-ID", announcement.BundleId).
+			Msg("ignoring invalid traffic policy")
+
+		d.policy = nil
+	}
+
+	if err := config.ValidateHTTPRoutes(d.routes); err != nil {
+		logging.DefaultLogger.Error().Err(err).
+			Str("Node-ID", nodeID).
+			Str("Bundle-ID", announcement.BundleId).
+			Msg("ignoring invalid routes")
+
+		d.routes = nil
+	}
+
+	if err := config.ValidateServicePorts(d.ports); err != nil {
+		logging.DefaultLogger.Error().Err(err).
+			Str("Node-ID", nodeID).
+			Str("Bundle-ID", announcement.BundleId).
+			Msg("ignoring invalid service ports")
+
+		d.ports = nil
+	}
+
+	return d
+}
+
+// addPort adds the provided port to the provided version. A port is served by exactly one version, e.g., after an
+// update that reused the port.
+func addPort(versions map[string][]int32, version string, port int32) {
+	removePort(versions, "", port)
+
+	versions[version] = append(versions[version], port)
+
+	// Remove duplicate ports
+	slices.Sort(versions[version])
+	versions[version] = slices.Compact(versions[version])
+}
+
+// removePort removes the provided port from the provided version. An unknown version matches all versions.
+func removePort(versions map[string][]int32, version string, port int32) {
+	for v, ports := range versions {
+		if version != "" && v != version {
+			continue
+		}
+
+		if idxPort := slices.Index(ports, port); idxPort > -1 {
+			versions[v] = slices.Delete(ports, idxPort, idxPort+1)
+		}
+
+		if len(versions[v]) == 0 {
+			delete(versions, v)
+		}
+	}
+}
+
 // ServiceConfigSnapshot represents a mapping of service to nodes at one point in time.
-type ServiceConfigSnapshot map[string]map[string][]int32
+type ServiceConfigSnapshot map[string]map[string]*ServiceConfig
+
+// Clone creates a deep copy of the snapshot.
+func (s ServiceConfigSnapshot) Clone() ServiceConfigSnapshot {
+	c := make(ServiceConfigSnapshot, len(s))
+	for nodeID, serviceConfig := range s {
+		c[nodeID] = make(map[string]*ServiceConfig, len(serviceConfig))
+		for bundleID, service := range serviceConfig {
+			c[nodeID][bundleID] = &ServiceConfig{
+				Versions:     make(map[string][]int32, len(service.Versions)),
+				Policy:       service.Policy,
+				Protocol:     service.Protocol,
+				Routes:       service.Routes,
+				Ports:        service.Ports,
+				Criticality:  service.Criticality,
+				CallerTokens: slices.Clone(service.CallerTokens),
+			}
+
+			for version, ports := range service.Versions {
+				c[nodeID][bundleID].Versions[version] = slices.Clone(ports)
+			}
+
+			if service.Unhealthy != nil {
+				c[nodeID][bundleID].Unhealthy = make(map[string][]int32, len(service.Unhealthy))
+				for version, ports := range service.Unhealthy {
+					c[nodeID][bundleID].Unhealthy[version] = slices.Clone(ports)
+				}
+			}
+
+			if service.PortVersions != nil {
+				c[nodeID][bundleID].PortVersions = make(map[string]map[string][]int32, len(service.PortVersions))
+				for name, versions := range service.PortVersions {
+					c[nodeID][bundleID].PortVersions[name] = make(map[string][]int32, len(versions))
+					for version, ports := range versions {
+						c[nodeID][bundleID].PortVersions[name][version] = slices.Clone(ports)
+					}
+				}
+			}
+		}
+	}
+
+	return c
+}
 
 // ServiceRegistryServer implements the node registry server.
 type ServiceRegistryServer struct {
@@ -31,21 +177,40 @@ type ServiceRegistryServer struct {
 
 	nodeRegistry *NodeRegistryServer
 
-	updateChannel chan<- ServiceConfigSnapshot
+	publisher publisher[ServiceConfigSnapshot]
+
+	store *Store
+
+	statsMu sync.Mutex // protects stats
+	stats   map[string]NodeStats
 }
 
-// OpenChannel opens a gRPC channel that processes service announcements and updates the service registry accordingly.
-func (s *ServiceRegistryServer) OpenChannel(stream pb.ServiceRegistryService_OpenChannelServer) error {
+// nodeID returns the validated node ID presented by the caller.
+func (s *ServiceRegistryServer) nodeID(ctx context.Context) (string, error) {
 	// try to retrieve metadata
-	md, ok := metadata.FromIncomingContext(stream.Context())
+	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return status.Errorf(codes.FailedPrecondition, errorMsgMissingHeader)
+		return "", status.Errorf(codes.FailedPrecondition, errorMsgMissingHeader)
 	}
 
 	// check if CARISMA header is set and validate value
 	nodeID := md.Get(HeaderNodeID)
-	if _, err := s.nodeRegistry.ValidateNodeID(nodeID[0]); len(nodeID) < 1 || err != nil {
-		return status.Errorf(codes.FailedPrecondition, errorMsgMissingHeader)
+	if len(nodeID) < 1 {
+		return "", status.Errorf(codes.FailedPrecondition, errorMsgMissingHeader)
+	}
+
+	if _, err := s.nodeRegistry.ValidateNodeID(nodeID[0]); err != nil {
+		return "", status.Errorf(codes.FailedPrecondition, err.Error())
+	}
+
+	return nodeID[0], nil
+}
+
+// OpenChannel opens a gRPC channel that processes service announcements and updates the service registry accordingly.
+func (s *ServiceRegistryServer) OpenChannel(stream pb.ServiceRegistryService_OpenChannelServer) error {
+	nodeID, err := s.nodeID(stream.Context())
+	if err != nil {
+		return err
 	}
 
 	// process all announcement messages and invoke the respective register/unregister method
@@ -59,67 +224,232 @@ func (s *ServiceRegistryServer) OpenChannel(stream pb.ServiceRegistryService_Ope
 		}
 
 		if announcement.RegistrationState == pb.ServiceAnnouncement_REGISTRATION_STATE_REGISTERED {
-			s.registerService(nodeID[0], announcement.BundleId, announcement.LocalPort)
+			_, localPorts := ServicePortsFromProto(announcement.Ports)
+
+			s.registerService(nodeID, announcement.BundleId, announcement.BundleVersion, announcement.LocalPort,
+				declarationFromAnnouncement(nodeID, announcement), localPorts, announcement.CallerToken)
 
 			logging.DefaultLogger.Info().
-				Str("Node-ID", nodeID[0]).
+				Str("Node-ID", nodeID).
 				Str("Bundle-ID", announcement.BundleId).
+				Str("Bundle-Version", announcement.BundleVersion).
 				Int32("Port", announcement.LocalPort).
 				Msg("Registered service")
 		} else {
-			s.unregisterService(nodeID[0], announcement.BundleId, announcement.LocalPort)
+			_, localPorts := ServicePortsFromProto(announcement.Ports)
+
+			s.unregisterService(nodeID, announcement.BundleId, announcement.BundleVersion, announcement.LocalPort, localPorts,
+				announcement.CallerToken)
 
 			logging.DefaultLogger.Info().
-				Str("Node-ID", nodeID[0]).
+				Str("Node-ID", nodeID).
 				Str("Bundle-ID", announcement.BundleId).
+				Str("Bundle-Version", announcement.BundleVersion).
 				Int32("Port", announcement.LocalPort).
 				Msg("Unregistered service")
 		}
 	}
 }
 
-func (s *ServiceRegistryServer) registerService(nodeID string, bundleID string, port int32) {
+// ReportHealth records which instances of the calling node Envoy considers unhealthy. Each report replaces the previous
+// report of the node, the service registry is only updated if the health of an instance changed.
+func (s *ServiceRegistryServer) ReportHealth(ctx context.Context, report *pb.HealthReport) (*emptypb.Empty, error) {
+	nodeID, err := s.nodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateHealth(nodeID, report.UnhealthyPorts)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServiceRegistryServer) updateHealth(nodeID string, unhealthyPorts []int32) {
+	s.mu.Lock()
+
+	changed := false
+	for bundleID, service := range s.services[nodeID] {
+		var unhealthy map[string][]int32
+		for version, ports := range service.Versions {
+			for _, port := range ports {
+				if !slices.Contains(unhealthyPorts, port) {
+					continue
+				}
+
+				if unhealthy == nil {
+					unhealthy = make(map[string][]int32)
+				}
+
+				unhealthy[version] = append(unhealthy[version], port)
+			}
+		}
+
+		if maps.EqualFunc(service.Unhealthy, unhealthy, slices.Equal[[]int32]) {
+			continue
+		}
+
+		service.Unhealthy = unhealthy
+		changed = true
+
+		logging.DefaultLogger.Info().
+			Str("Node-ID", nodeID).
+			Str("Bundle-ID", bundleID).
+			Interface("Unhealthy", unhealthy).
+			Msg("Service health changed")
+	}
+
+	if !changed {
+		s.mu.Unlock()
+
+		return
+	}
+
+	err := s.store.SaveServices(s.services)
+	logging.LogErr(err)
+
+	s.mu.Unlock()
+
+	s.publishServices()
+}
+
+func (s *ServiceRegistryServer) registerService(nodeID string, bundleID string, version string, port int32, d declaration,
+	localPorts map[string]int32, callerToken string) {
 	s.mu.Lock()
 
 	if _, ok := s.services[nodeID]; !ok {
-		s.services[nodeID] = make(map[string][]int32)
+		s.services[nodeID] = make(map[string]*ServiceConfig)
 	}
 
-	s.services[nodeID][bundleID] = append(s.services[nodeID][bundleID], port)
+	service, ok := s.services[nodeID][bundleID]
+	if !ok {
+		service = &ServiceConfig{Versions: make(map[string][]int32)}
+		s.services[nodeID][bundleID] = service
+	}
 
-	// Remove duplicate ports
-	slices.Sort(s.services[nodeID][bundleID])
-	s.services[nodeID][bundleID] = slices.Compact(s.services[nodeID][bundleID])
+	// bundles that only serve TCP or UDP service ports do not have a gRPC or HTTP port
+	if port > 0 {
+		addPort(service.Versions, version, port)
+
+		// a (re-)registered instance is considered healthy until Envoy reports otherwise
+		removePort(service.Unhealthy, "", port)
+	}
+
+	service.Policy = d.policy
+	service.Protocol = d.protocol
+	service.Routes = d.routes
+	service.Ports = d.ports
+	service.Criticality = d.criticality
+
+	for _, p := range d.ports {
+		localPort, ok := localPorts[p.Name]
+		if !ok {
+			continue
+		}
+
+		if service.PortVersions == nil {
+			service.PortVersions = make(map[string]map[string][]int32)
+		}
+
+		if _, ok := service.PortVersions[p.Name]; !ok {
+			service.PortVersions[p.Name] = make(map[string][]int32)
+		}
+
+		addPort(service.PortVersions[p.Name], version, localPort)
+	}
+
+	// instances started without caller token call other bundles anonymously
+	if callerToken != "" && !slices.Contains(service.CallerTokens, callerToken) {
+		service.CallerTokens = append(service.CallerTokens, callerToken)
+		slices.Sort(service.CallerTokens)
+	}
+
+	err := s.store.SaveServices(s.services)
+	logging.LogErr(err)
 
 	s.mu.Unlock()
 
-	s.updateChannel <- s.services
+	s.publishServices()
 }
 
-func (s *ServiceRegistryServer) unregisterService(nodeID string, bundleID string, port int32) {
+func (s *ServiceRegistryServer) unregisterService(nodeID string, bundleID string, version string, port int32, localPorts map[string]int32,
+	callerToken string) {
 	s.mu.Lock()
 
-	idxPort := slices.Index(s.services[nodeID][bundleID], port)
-	if idxPort > -1 {
-		s.services[nodeID][bundleID] = slices.Delete(s.services[nodeID][bundleID], idxPort, idxPort+1)
+	if service, ok := s.services[nodeID][bundleID]; ok {
+		removePort(service.Versions, version, port)
+		removePort(service.Unhealthy, version, port)
+
+		for name, localPort := range localPorts {
+			if versions, ok := service.PortVersions[name]; ok {
+				removePort(versions, version, localPort)
+
+				if len(versions) == 0 {
+					delete(service.PortVersions, name)
+				}
+			}
+		}
+
+		if idx := slices.Index(service.CallerTokens, callerToken); idx > -1 {
+			service.CallerTokens = slices.Delete(service.CallerTokens, idx, idx+1)
+		}
+
+		if len(service.Versions) == 0 && len(service.PortVersions) == 0 {
+			delete(s.services[nodeID], bundleID)
+		}
 	}
 
-	if len(s.services[nodeID][bundleID]) == 0 {
-		delete(s.services[nodeID], bundleID)
+	err := s.store.SaveServices(s.services)
+	logging.LogErr(err)
+
+	s.mu.Unlock()
+
+	s.publishServices()
+}
+
+// EvictNode removes all services of the node with the provided ID.
+func (s *ServiceRegistryServer) EvictNode(nodeID string) {
+	s.mu.Lock()
+
+	if _, ok := s.services[nodeID]; !ok {
+		s.mu.Unlock()
+
+		return
 	}
 
+	delete(s.services, nodeID)
+
+	err := s.store.SaveServices(s.services)
+	logging.LogErr(err)
+
 	s.mu.Unlock()
 
-	s.updateChannel <- s.services
+	logging.DefaultLogger.Info().
+		Str("Node-ID", nodeID).
+		Msg("Evicted services of node")
+
+	s.publishServices()
+}
+
+// publishServices sends the current services to the xDS server. The caller must not hold the lock, as the receiver
+// acquires it.
+func (s *ServiceRegistryServer) publishServices() {
+	s.publisher.publish(func() ServiceConfigSnapshot {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		return s.services.Clone()
+	})
 }
 
-// NewServiceRegistryServer creates a new instance of the ServiceRegistryServer.
-func NewServiceRegistryServer(mu *sync.RWMutex, nReg *NodeRegistryServer, uC chan<- ServiceConfigSnapshot) *ServiceRegistryServer {
+// NewServiceRegistryServer creates a new instance of the ServiceRegistryServer and restores the services persisted in the store.
+func NewServiceRegistryServer(mu *sync.RWMutex, nReg *NodeRegistryServer, uC chan<- ServiceConfigSnapshot, store *Store) *ServiceRegistryServer {
 	s := &ServiceRegistryServer{
-		mu:            mu,
-		services:      make(map[string]map[string][]int32),
-		updateChannel: uC,
-		nodeRegistry:  nReg,
+		mu:           mu,
+		services:     store.Services(),
+		publisher:    publisher[ServiceConfigSnapshot]{ch: uC},
+		nodeRegistry: nReg,
+		store:        store,
+		stats:        make(map[string]NodeStats),
 	}
 
 	return s
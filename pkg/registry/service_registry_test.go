@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"fmt"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	"gotest.tools/v3/assert"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestServiceRegistryServer(t *testing.T) (*ServiceRegistryServer, chan ServiceConfigSnapshot) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	chanServices := make(chan ServiceConfigSnapshot, 10)
+
+	return NewServiceRegistryServer(&sync.RWMutex{}, nil, chanServices, store), chanServices
+}
+
+func TestRegisterServicePorts(t *testing.T) {
+	s, chanServices := newTestServiceRegistryServer(t)
+
+	d := declaration{ports: []config.ServicePort{{Name: "mqtt", Protocol: config.TransportProtocolTCP, Port: 20000, TargetPort: 1883}}}
+
+	// a bundle that only serves a TCP service port
+	s.registerService("node-hpc-1", "broker", "v1", -1, d, map[string]int32{"mqtt": 32768}, "")
+	services := <-chanServices
+
+	service := services["node-hpc-1"]["broker"]
+	assert.Equal(t, len(service.Versions), 0)
+	assert.DeepEqual(t, service.PortVersions, map[string]map[string][]int32{"mqtt": {"v1": {32768}}})
+
+	s.unregisterService("node-hpc-1", "broker", "v1", -1, map[string]int32{"mqtt": 32768}, "")
+	services = <-chanServices
+
+	_, ok := services["node-hpc-1"]["broker"]
+	assert.Assert(t, !ok)
+}
+
+func TestUpdateHealth(t *testing.T) {
+	s, chanServices := newTestServiceRegistryServer(t)
+
+	s.registerService("node-hpc-1", "app", "v1", 8080, declaration{}, nil, "")
+	<-chanServices
+	s.registerService("node-hpc-1", "app", "v1", 8081, declaration{}, nil, "")
+	<-chanServices
+
+	s.updateHealth("node-hpc-1", []int32{8080, 9999})
+	services := <-chanServices
+
+	service := services["node-hpc-1"]["app"]
+	assert.DeepEqual(t, service.Unhealthy, map[string][]int32{"v1": {8080}})
+	assert.Assert(t, service.Healthy("v1"))
+
+	// an unchanged report does not update the registry
+	s.updateHealth("node-hpc-1", []int32{8080})
+	assert.Equal(t, len(chanServices), 0)
+
+	s.updateHealth("node-hpc-1", []int32{8080, 8081})
+	services = <-chanServices
+	assert.Assert(t, !services["node-hpc-1"]["app"].Healthy("v1"))
+
+	// a re-registered instance is considered healthy again
+	s.registerService("node-hpc-1", "app", "v1", 8081, declaration{}, nil, "")
+	services = <-chanServices
+	assert.DeepEqual(t, services["node-hpc-1"]["app"].Unhealthy, map[string][]int32{"v1": {8080}})
+}
+
+func TestCallerTokens(t *testing.T) {
+	s, chanServices := newTestServiceRegistryServer(t)
+
+	s.registerService("node-hpc-1", "app", "v1", 8080, declaration{}, nil, "token-b")
+	<-chanServices
+	s.registerService("node-hpc-1", "app", "v2", 8081, declaration{}, nil, "token-a")
+	services := <-chanServices
+	assert.DeepEqual(t, services["node-hpc-1"]["app"].CallerTokens, []string{"token-a", "token-b"})
+
+	s.unregisterService("node-hpc-1", "app", "v1", 8080, nil, "token-b")
+	services = <-chanServices
+	assert.DeepEqual(t, services["node-hpc-1"]["app"].CallerTokens, []string{"token-a"})
+
+	// the snapshot does not share the caller tokens with the registry
+	services["node-hpc-1"]["app"].CallerTokens[0] = "token-c"
+	s.mu.RLock()
+	assert.DeepEqual(t, s.services["node-hpc-1"]["app"].CallerTokens, []string{"token-a"})
+	s.mu.RUnlock()
+}
+
+func TestDeclaredCriticality(t *testing.T) {
+	s, chanServices := newTestServiceRegistryServer(t)
+
+	d := declarationFromAnnouncement("node-hpc-1", &pb.ServiceAnnouncement{
+		BundleId:    "brake",
+		Criticality: CriticalityToProto(config.CriticalityASILD),
+	})
+
+	s.registerService("node-hpc-1", "brake", "v1", 8080, d, nil, "")
+	services := <-chanServices
+
+	assert.Equal(t, services["node-hpc-1"]["brake"].Criticality, config.CriticalityASILD)
+	assert.Equal(t, services.Clone()["node-hpc-1"]["brake"].Criticality, config.CriticalityASILD)
+}
+
+func TestConcurrentRegistrationsPublishLatestServices(t *testing.T) {
+	s, chanServices := newTestServiceRegistryServer(t)
+
+	const numBundles = 20
+
+	var wg sync.WaitGroup
+	wg.Add(numBundles)
+
+	for idx := 0; idx < numBundles; idx++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			s.registerService("node-hpc-1", fmt.Sprintf("app-%d", idx), "v1", int32(8080+idx), declaration{}, nil, "")
+		}(idx)
+	}
+
+	// like the xDS server, the receiver acquires the lock of the registry for every snapshot
+	var services ServiceConfigSnapshot
+	for idx := 0; idx < numBundles; idx++ {
+		services = <-chanServices
+
+		s.mu.Lock()
+		time.Sleep(time.Millisecond)
+		s.mu.Unlock()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, len(services["node-hpc-1"]), numBundles)
+}
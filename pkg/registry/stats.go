@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"encoding/json"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/service/v1"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// localClusterPrefix is the name prefix of the clusters that only contain the local instances of a bundle.
+const localClusterPrefix = "local_"
+
+// ClusterStats encodes the upstream statistics of one cluster of Envoy on a node. Counters are cumulative since Envoy
+// started.
+type ClusterStats struct {
+	Cluster      string  `json:"cluster"`
+	Requests     uint64  `json:"requests"`
+	Errors       uint64  `json:"errors"`
+	Timeouts     uint64  `json:"timeouts"`
+	LatencyP50Ms float64 `json:"latencyP50Ms"`
+	LatencyP90Ms float64 `json:"latencyP90Ms"`
+	LatencyP99Ms float64 `json:"latencyP99Ms"`
+}
+
+// NodeStats encodes the most recent statistics report of a node.
+type NodeStats struct {
+	NodeID   string         `json:"nodeID"`
+	Updated  time.Time      `json:"updated"`
+	Clusters []ClusterStats `json:"clusters"`
+}
+
+// ClusterTotals encodes the requests to one cluster summed up over all nodes.
+type ClusterTotals struct {
+	Requests uint64 `json:"requests"`
+	Errors   uint64 `json:"errors"`
+	Timeouts uint64 `json:"timeouts"`
+}
+
+type statsDump struct {
+	Clusters map[string]ClusterTotals `json:"clusters"`
+	Nodes    []NodeStats              `json:"nodes"`
+}
+
+func clusterStatsFromProto(clusters []*pb.ClusterStats) []ClusterStats {
+	stats := make([]ClusterStats, 0, len(clusters))
+	for _, c := range clusters {
+		stats = append(stats, ClusterStats{
+			Cluster:      c.Cluster,
+			Requests:     c.Requests,
+			Errors:       c.Errors,
+			Timeouts:     c.Timeouts,
+			LatencyP50Ms: c.LatencyP50Ms,
+			LatencyP90Ms: c.LatencyP90Ms,
+			LatencyP99Ms: c.LatencyP99Ms,
+		})
+	}
+
+	slices.SortFunc(stats, func(a, b ClusterStats) int {
+		return strings.Compare(a.Cluster, b.Cluster)
+	})
+
+	return stats
+}
+
+// ReportStats receives the statistics reports of the calling node. Each report replaces the previous report of the node.
+func (s *ServiceRegistryServer) ReportStats(stream pb.ServiceRegistryService_ReportStatsServer) error {
+	nodeID, err := s.nodeID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	for {
+		report, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&emptypb.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+
+		s.statsMu.Lock()
+		s.stats[nodeID] = NodeStats{NodeID: nodeID, Updated: time.Now(), Clusters: clusterStatsFromProto(report.Clusters)}
+		s.statsMu.Unlock()
+	}
+}
+
+// Stats returns the most recent statistics of the registered nodes ordered by node ID. The statistics of nodes that left
+// the registry are dropped.
+func (s *ServiceRegistryServer) Stats() []NodeStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	nodeIDs := maps.Keys(s.stats)
+	slices.Sort(nodeIDs)
+
+	stats := make([]NodeStats, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if s.nodeRegistry != nil {
+			if _, err := s.nodeRegistry.ValidateNodeID(nodeID); err != nil {
+				delete(s.stats, nodeID)
+
+				continue
+			}
+		}
+
+		stats = append(stats, s.stats[nodeID])
+	}
+
+	return stats
+}
+
+// totals sums up the requests of all nodes per bundle cluster. Only the clusters used by the callers are considered, as
+// the requests forwarded to the local instances of a node are already counted by the calling node.
+func totals(stats []NodeStats) map[string]ClusterTotals {
+	clusters := make(map[string]ClusterTotals)
+	for _, n := range stats {
+		for _, c := range n.Clusters {
+			if strings.HasPrefix(c.Cluster, localClusterPrefix) {
+				continue
+			}
+
+			t := clusters[c.Cluster]
+			t.Requests += c.Requests
+			t.Errors += c.Errors
+			t.Timeouts += c.Timeouts
+			clusters[c.Cluster] = t
+		}
+	}
+
+	return clusters
+}
+
+func (s *ServiceRegistryServer) handleStats(w http.ResponseWriter, _ *http.Request) {
+	stats := s.Stats()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(statsDump{Clusters: totals(stats), Nodes: stats})
+	logging.LogErr(err)
+}
+
+// RegisterHandlers registers the read-only HTTP API that presents the upstream statistics reported by the nodes, both
+// per node and summed up per cluster.
+func (s *ServiceRegistryServer) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /stats", s.handleStats)
+}
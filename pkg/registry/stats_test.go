@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestTotals(t *testing.T) {
+	stats := []NodeStats{
+		{NodeID: "node-hpc-1", Clusters: []ClusterStats{
+			{Cluster: "app_cluster", Requests: 10, Errors: 2},
+			{Cluster: "local_app_cluster", Requests: 5, Errors: 1},
+		}},
+		{NodeID: "node-hpc-2", Clusters: []ClusterStats{
+			{Cluster: "app_cluster", Requests: 5, Timeouts: 1},
+		}},
+	}
+
+	// the requests forwarded to the local instances were already counted by the callers
+	assert.DeepEqual(t, totals(stats), map[string]ClusterTotals{
+		"app_cluster": {Requests: 15, Errors: 2, Timeouts: 1},
+	})
+}
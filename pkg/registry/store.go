@@ -0,0 +1,369 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"golang.org/x/exp/slices"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// Version of the format of the persisted state. Files without version are migrated, see migrateStoreState.
+	storeFormatVersion = 1
+
+	// Bundle version of services persisted before bundle versions were tracked, which matches the version of untagged
+	// container images.
+	legacyBundleVersion = "latest"
+)
+
+// StoreState encodes the persisted state of the node and service registry, the most recent desired deployment
+// configuration and the leadership term of the control plane.
+type StoreState struct {
+	Version  int                   `json:"version"`
+	Nodes    NodeSnapshot          `json:"nodes"`
+	Services ServiceConfigSnapshot `json:"services"`
+	Weights  WeightSnapshot        `json:"weights"`
+	Desired  json.RawMessage       `json:"desired,omitempty"`
+	Term     uint64                `json:"term,omitempty"`
+}
+
+// Store persists the state of the node and service registry in a local file.
+type Store struct {
+	mu       sync.Mutex // protects state, changed and the underlying file
+	filePath string
+	state    StoreState
+	changed  chan struct{}
+}
+
+// NewStore creates a new instance of Store and loads the state persisted at the provided file path, if present.
+func NewStore(filePath string) (*Store, error) {
+	s := &Store{
+		filePath: filePath,
+		state: StoreState{
+			Version:  storeFormatVersion,
+			Nodes:    make(NodeSnapshot),
+			Services: make(ServiceConfigSnapshot),
+			Weights:  make(WeightSnapshot),
+		},
+		changed: make(chan struct{}),
+	}
+
+	j, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return s, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if s.state, err = decodeStoreState(j); err != nil {
+		return nil, err
+	}
+
+	s.initialize()
+
+	return s, nil
+}
+
+// decodeStoreState decodes the provided persisted state and migrates it to the current format if necessary.
+func decodeStoreState(j []byte) (StoreState, error) {
+	var version struct {
+		Version int `json:"version"`
+	}
+
+	if err := json.Unmarshal(j, &version); err != nil {
+		return StoreState{}, err
+	}
+
+	switch {
+	case version.Version == 0:
+		return migrateStoreState(j)
+	case version.Version > storeFormatVersion:
+		return StoreState{}, fmt.Errorf("unsupported version of persisted registry: %d", version.Version)
+	}
+
+	var state StoreState
+	err := json.Unmarshal(j, &state)
+
+	return state, err
+}
+
+// migrateStoreState converts a state persisted before the format was versioned. Initially, the nodes were persisted as
+// list of addresses, whose index made up the node ID, and the services as local ports per bundle.
+func migrateStoreState(j []byte) (StoreState, error) {
+	var legacy struct {
+		Nodes    json.RawMessage                       `json:"nodes"`
+		Services map[string]map[string]json.RawMessage `json:"services"`
+		Weights  WeightSnapshot                        `json:"weights"`
+		Desired  json.RawMessage                       `json:"desired,omitempty"`
+		Term     uint64                                `json:"term,omitempty"`
+	}
+
+	if err := json.Unmarshal(j, &legacy); err != nil {
+		return StoreState{}, err
+	}
+
+	state := StoreState{
+		Version:  storeFormatVersion,
+		Nodes:    make(NodeSnapshot),
+		Services: make(ServiceConfigSnapshot, len(legacy.Services)),
+		Weights:  legacy.Weights,
+		Desired:  legacy.Desired,
+		Term:     legacy.Term,
+	}
+
+	// maps the node IDs derived from the list index to the node IDs derived from the hostname
+	nodeIDs := make(map[string]string)
+
+	if nodes := bytes.TrimSpace(legacy.Nodes); len(nodes) > 0 && nodes[0] == '[' {
+		var addrs []*NodeAddr
+		if err := json.Unmarshal(nodes, &addrs); err != nil {
+			return StoreState{}, err
+		}
+
+		for idx, addr := range addrs {
+			nodeID := NodeIDFromHostname(addr.Host)
+			if _, ok := state.Nodes[nodeID]; ok {
+				continue
+			}
+
+			state.Nodes[nodeID] = &Node{Hostname: addr.Host, Addr: &NodeAddr{Host: addr.Host, Port: addr.Port}}
+			nodeIDs[fmt.Sprintf("node-%v", idx)] = nodeID
+		}
+	} else if len(nodes) > 0 && !bytes.Equal(nodes, []byte("null")) {
+		if err := json.Unmarshal(nodes, &state.Nodes); err != nil {
+			return StoreState{}, err
+		}
+	}
+
+	for nodeID, bundles := range legacy.Services {
+		if migratedNodeID, ok := nodeIDs[nodeID]; ok {
+			nodeID = migratedNodeID
+		}
+
+		state.Services[nodeID] = make(map[string]*ServiceConfig, len(bundles))
+		for bundleID, raw := range bundles {
+			service, err := migrateServiceConfig(raw)
+			if err != nil {
+				return StoreState{}, err
+			}
+
+			state.Services[nodeID][bundleID] = service
+		}
+	}
+
+	return state, nil
+}
+
+// migrateServiceConfig decodes a persisted service, which may be a list of local ports, local ports together with a
+// traffic policy, or a service of the current format, which groups the local ports by bundle version.
+func migrateServiceConfig(raw json.RawMessage) (*ServiceConfig, error) {
+	var ports []int32
+	if err := json.Unmarshal(raw, &ports); err == nil {
+		return &ServiceConfig{Versions: map[string][]int32{legacyBundleVersion: ports}}, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	if _, ok := fields["versions"]; !ok {
+		var portsWithPolicy struct {
+			Ports  []int32               `json:"ports"`
+			Policy *config.TrafficPolicy `json:"policy,omitempty"`
+		}
+
+		if err := json.Unmarshal(raw, &portsWithPolicy); err != nil {
+			return nil, err
+		}
+
+		return &ServiceConfig{
+			Versions: map[string][]int32{legacyBundleVersion: portsWithPolicy.Ports},
+			Policy:   portsWithPolicy.Policy,
+		}, nil
+	}
+
+	var service ServiceConfig
+	if err := json.Unmarshal(raw, &service); err != nil {
+		return nil, err
+	}
+
+	return &service, nil
+}
+
+// initialize replaces the missing parts of the state by empty ones.
+func (s *Store) initialize() {
+	if s.state.Nodes == nil {
+		s.state.Nodes = make(NodeSnapshot)
+	}
+
+	if s.state.Services == nil {
+		s.state.Services = make(ServiceConfigSnapshot)
+	}
+
+	if s.state.Weights == nil {
+		s.state.Weights = make(WeightSnapshot)
+	}
+
+	s.state.Version = storeFormatVersion
+}
+
+// Nodes returns the persisted nodes.
+func (s *Store) Nodes() NodeSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.Nodes.Clone()
+}
+
+// Services returns the persisted services.
+func (s *Store) Services() ServiceConfigSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.Services.Clone()
+}
+
+// Weights returns the persisted traffic weights.
+func (s *Store) Weights() WeightSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.Weights.Clone()
+}
+
+// SaveNodes persists the provided nodes.
+func (s *Store) SaveNodes(nodes NodeSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.Nodes = nodes.Clone()
+
+	return s.write()
+}
+
+// SaveServices persists the provided services.
+func (s *Store) SaveServices(services ServiceConfigSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.Services = services.Clone()
+
+	return s.write()
+}
+
+// SaveWeights persists the provided traffic weights.
+func (s *Store) SaveWeights(weights WeightSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.Weights = weights.Clone()
+
+	return s.write()
+}
+
+// Desired returns the persisted desired deployment configuration in JSON format, if any.
+func (s *Store) Desired() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.state.Desired)
+}
+
+// SaveDesired persists the provided desired deployment configuration in JSON format.
+func (s *Store) SaveDesired(desired []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !json.Valid(desired) {
+		return errors.New("invalid desired deployment configuration")
+	}
+
+	s.state.Desired = slices.Clone(desired)
+
+	return s.write()
+}
+
+// Term returns the persisted leadership term.
+func (s *Store) Term() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.Term
+}
+
+// SaveTerm persists the provided leadership term.
+func (s *Store) SaveTerm(term uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.Term = term
+
+	return s.write()
+}
+
+// State returns the persisted state in JSON format.
+func (s *Store) State() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return json.Marshal(s.state)
+}
+
+// ReplaceState persists the provided state in JSON format as a whole, e.g., the state replicated from the leading
+// control plane.
+func (s *Store) ReplaceState(j []byte) error {
+	state, err := decodeStoreState(j)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = state
+	s.initialize()
+
+	return s.write()
+}
+
+// Changed returns a channel that is closed with the next change of the persisted state.
+func (s *Store) Changed() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.changed
+}
+
+// write atomically replaces the file content with the current state and notifies the watchers of the state.
+func (s *Store) write() error {
+	defer func() {
+		close(s.changed)
+		s.changed = make(chan struct{})
+	}()
+
+	j, err := json.MarshalIndent(s.state, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0755); err != nil {
+		return err
+	}
+
+	// the state contains the caller tokens of the bundles
+	tmpFilePath := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, j, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilePath, s.filePath)
+}
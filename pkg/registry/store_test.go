@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/config"
+	"gotest.tools/v3/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreWithoutFile(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(store.Nodes()), 0)
+	assert.Equal(t, len(store.Services()), 0)
+	assert.Equal(t, len(store.Weights()), 0)
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "data", "registry.json")
+
+	nodes := NodeSnapshot{
+		"node-host-1": {Hostname: "host-1", Addr: &NodeAddr{Host: "host-1", Port: 8000}},
+		"node-host-2": {Hostname: "host-2", Addr: &NodeAddr{Host: "10.0.0.2", Port: 8200}},
+	}
+
+	services := ServiceConfigSnapshot{
+		"node-host-1": {
+			"com.mercedes_benz.app_1": {Versions: map[string][]int32{"v1": {8080}, "v2": {8081}}},
+			"com.mercedes_benz.app_2": {
+				Versions: map[string][]int32{"latest": {8082}},
+				Policy: &config.TrafficPolicy{
+					TimeoutMs: 500,
+					Retry:     &config.RetryPolicy{RetryOn: []string{"unavailable"}, NumRetries: 2},
+				},
+			},
+		},
+	}
+
+	weights := WeightSnapshot{
+		"com.mercedes_benz.app_1": {"v1": 90, "v2": 10},
+	}
+
+	store, err := NewStore(filePath)
+	assert.NilError(t, err)
+
+	assert.NilError(t, store.SaveNodes(nodes))
+	assert.NilError(t, store.SaveServices(services))
+	assert.NilError(t, store.SaveWeights(weights))
+
+	restoredStore, err := NewStore(filePath)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, restoredStore.Nodes(), nodes)
+	assert.DeepEqual(t, restoredStore.Services(), services)
+	assert.DeepEqual(t, restoredStore.Weights(), weights)
+}
+
+func TestStoreMigratesLegacyState(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "registry.json")
+
+	// nodes and services as persisted before node IDs were derived from hostnames and bundle versions were tracked
+	legacy := `{
+		"nodes": [{"host": "hpc-1", "port": 8000}, {"host": "10.0.0.2", "port": 8200}],
+		"services": {
+			"node-0": {"com.mercedes_benz.app_1": [8080]},
+			"node-1": {"com.mercedes_benz.app_2": {"ports": [8081], "policy": {"timeout_ms": 500}}}
+		}
+	}`
+	assert.NilError(t, os.WriteFile(filePath, []byte(legacy), 0644))
+
+	store, err := NewStore(filePath)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, store.Nodes(), NodeSnapshot{
+		"node-hpc-1":    {Hostname: "hpc-1", Addr: &NodeAddr{Host: "hpc-1", Port: 8000}},
+		"node-10-0-0-2": {Hostname: "10.0.0.2", Addr: &NodeAddr{Host: "10.0.0.2", Port: 8200}},
+	})
+	assert.DeepEqual(t, store.Services(), ServiceConfigSnapshot{
+		"node-hpc-1": {
+			"com.mercedes_benz.app_1": {Versions: map[string][]int32{legacyBundleVersion: {8080}}},
+		},
+		"node-10-0-0-2": {
+			"com.mercedes_benz.app_2": {
+				Versions: map[string][]int32{legacyBundleVersion: {8081}},
+				Policy:   &config.TrafficPolicy{TimeoutMs: 500},
+			},
+		},
+	})
+}
+
+func TestStoreRejectsUnsupportedVersion(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "registry.json")
+	assert.NilError(t, os.WriteFile(filePath, []byte(`{"version": 99}`), 0644))
+
+	_, err := NewStore(filePath)
+	assert.ErrorContains(t, err, "unsupported version")
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/traffic/v1"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"math"
+	"sync"
+)
+
+// WeightSnapshot represents a mapping of bundles to the traffic weights of their versions at one point in time.
+type WeightSnapshot map[string]map[string]uint32
+
+// Clone creates a deep copy of the snapshot.
+func (w WeightSnapshot) Clone() WeightSnapshot {
+	c := make(WeightSnapshot, len(w))
+	for bundleID, weights := range w {
+		c[bundleID] = maps.Clone(weights)
+	}
+
+	return c
+}
+
+// TrafficServer implements the traffic server that manages the traffic split between the versions of a bundle.
+type TrafficServer struct {
+	pb.UnimplementedTrafficServiceServer
+
+	mu      *sync.RWMutex // protects weights
+	weights WeightSnapshot
+
+	publisher publisher[WeightSnapshot]
+
+	store *Store
+}
+
+// SetWeights replaces the traffic weights of the versions of a bundle. Versions without a weight do not receive any
+// traffic, unless no weights are set at all.
+func (t *TrafficServer) SetWeights(_ context.Context, req *pb.SetWeightsRequest) (*emptypb.Empty, error) {
+	if req.BundleId == "" {
+		return nil, status.Error(codes.InvalidArgument, errorMsgMissingBundleID)
+	}
+
+	// the sum must not overflow, as Envoy rejects weighted clusters whose total weight exceeds 32 bits
+	var total uint64
+	for _, weight := range req.Weights {
+		total += uint64(weight)
+	}
+
+	if len(req.Weights) > 0 && total == 0 {
+		return nil, status.Error(codes.InvalidArgument, errorMsgZeroWeights)
+	}
+
+	if total > math.MaxUint32 {
+		return nil, status.Error(codes.InvalidArgument, errorMsgExcessiveWeights)
+	}
+
+	t.mu.Lock()
+
+	if len(req.Weights) == 0 {
+		delete(t.weights, req.BundleId)
+	} else {
+		t.weights[req.BundleId] = maps.Clone(req.Weights)
+	}
+
+	err := t.store.SaveWeights(t.weights)
+	logging.LogErr(err)
+
+	t.mu.Unlock()
+
+	logging.DefaultLogger.Info().
+		Str("Bundle-ID", req.BundleId).
+		Interface("Weights", req.Weights).
+		Msg("Updated traffic weights")
+
+	t.publishWeights()
+
+	return &emptypb.Empty{}, nil
+}
+
+// ListWeights returns the traffic weights of all bundles or of the requested bundle.
+func (t *TrafficServer) ListWeights(_ context.Context, req *pb.ListWeightsRequest) (*pb.ListWeightsResponse, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	bundleIDs := maps.Keys(t.weights)
+	slices.Sort(bundleIDs)
+
+	resp := &pb.ListWeightsResponse{Bundles: make([]*pb.BundleWeights, 0, len(bundleIDs))}
+	for _, bundleID := range bundleIDs {
+		if req.BundleId != "" && req.BundleId != bundleID {
+			continue
+		}
+
+		resp.Bundles = append(resp.Bundles, &pb.BundleWeights{
+			BundleId: bundleID,
+			Weights:  maps.Clone(t.weights[bundleID]),
+		})
+	}
+
+	return resp, nil
+}
+
+// publishWeights sends the current traffic weights to the xDS server. The caller must not hold the lock, as the receiver
+// acquires it.
+func (t *TrafficServer) publishWeights() {
+	t.publisher.publish(func() WeightSnapshot {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+
+		return t.weights.Clone()
+	})
+}
+
+// NewTrafficServer creates a new instance of the TrafficServer and restores the traffic weights persisted in the store.
+func NewTrafficServer(mu *sync.RWMutex, uC chan<- WeightSnapshot, store *Store) *TrafficServer {
+	return &TrafficServer{
+		mu:        mu,
+		weights:   store.Weights(),
+		publisher: publisher[WeightSnapshot]{ch: uC},
+		store:     store,
+	}
+}
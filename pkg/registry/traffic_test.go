@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package registry
+
+import (
+	"context"
+	pb "github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/protobuf/carisma/traffic/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+const testBundleID = "com.mercedes_benz.app_1"
+
+func newTestTrafficServer(t *testing.T) (*TrafficServer, chan WeightSnapshot, *Store) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NilError(t, err)
+
+	chanWeights := make(chan WeightSnapshot, 10)
+
+	return NewTrafficServer(&sync.RWMutex{}, chanWeights, store), chanWeights, store
+}
+
+func TestSetWeights(t *testing.T) {
+	s, chanWeights, store := newTestTrafficServer(t)
+
+	_, err := s.SetWeights(context.Background(), &pb.SetWeightsRequest{
+		BundleId: testBundleID,
+		Weights:  map[string]uint32{"v1": 90, "v2": 10},
+	})
+	assert.NilError(t, err)
+
+	weights := <-chanWeights
+	assert.DeepEqual(t, weights[testBundleID], map[string]uint32{"v1": 90, "v2": 10})
+	assert.DeepEqual(t, store.Weights(), weights)
+
+	resp, err := s.ListWeights(context.Background(), &pb.ListWeightsRequest{BundleId: testBundleID})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Bundles), 1)
+	assert.DeepEqual(t, resp.Bundles[0].Weights, map[string]uint32{"v1": 90, "v2": 10})
+
+	// an empty map resets the bundle
+	_, err = s.SetWeights(context.Background(), &pb.SetWeightsRequest{BundleId: testBundleID})
+	assert.NilError(t, err)
+
+	weights = <-chanWeights
+	assert.Equal(t, len(weights), 0)
+}
+
+func TestSetInvalidWeights(t *testing.T) {
+	s, _, _ := newTestTrafficServer(t)
+
+	_, err := s.SetWeights(context.Background(), &pb.SetWeightsRequest{Weights: map[string]uint32{"v1": 100}})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	_, err = s.SetWeights(context.Background(), &pb.SetWeightsRequest{
+		BundleId: testBundleID,
+		Weights:  map[string]uint32{"v1": 0, "v2": 0},
+	})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+
+	// the total weight would wrap around in 32 bits
+	_, err = s.SetWeights(context.Background(), &pb.SetWeightsRequest{
+		BundleId: testBundleID,
+		Weights:  map[string]uint32{"v1": math.MaxUint32, "v2": 1},
+	})
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+	assert.ErrorContains(t, err, errorMsgExcessiveWeights)
+}
+
+func TestSetMaximumWeights(t *testing.T) {
+	s, chanWeights, _ := newTestTrafficServer(t)
+
+	_, err := s.SetWeights(context.Background(), &pb.SetWeightsRequest{
+		BundleId: testBundleID,
+		Weights:  map[string]uint32{"v1": math.MaxUint32 - 1, "v2": 1},
+	})
+	assert.NilError(t, err)
+
+	weights := <-chanWeights
+	assert.DeepEqual(t, weights[testBundleID], map[string]uint32{"v1": math.MaxUint32 - 1, "v2": 1})
+}
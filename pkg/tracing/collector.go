@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+// Package tracing implements a lightweight trace collector that receives the spans of the Envoys in the Zipkin v2 JSON
+// format, keeps the most recent traces in memory and serves them assembled by their trace ID.
+package tracing
+
+import (
+	"encoding/json"
+	"github.com/mercedes-benz/car-integrated-service-mesh-architecture/pkg/logging"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// SpansPath is the path Zipkin-compatible tracers report their spans to.
+	SpansPath = "/api/v2/spans"
+
+	// Maximum size of a span report and maximum number of spans kept per trace.
+	maxReportSize    = 4 << 20
+	maxSpansPerTrace = 1000
+)
+
+// Endpoint encodes the service and address of one side of a span.
+type Endpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	IPv4        string `json:"ipv4,omitempty"`
+	IPv6        string `json:"ipv6,omitempty"`
+	Port        int    `json:"port,omitempty"`
+}
+
+// Span encodes a span in the Zipkin v2 format. Timestamp and duration are given in microseconds.
+type Span struct {
+	TraceID        string            `json:"traceId"`
+	ID             string            `json:"id"`
+	ParentID       string            `json:"parentId,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Kind           string            `json:"kind,omitempty"`
+	Timestamp      int64             `json:"timestamp,omitempty"`
+	Duration       int64             `json:"duration,omitempty"`
+	Shared         bool              `json:"shared,omitempty"`
+	LocalEndpoint  *Endpoint         `json:"localEndpoint,omitempty"`
+	RemoteEndpoint *Endpoint         `json:"remoteEndpoint,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// Trace encodes the spans of a trace ordered by their start.
+type Trace struct {
+	TraceID  string `json:"traceId"`
+	Start    int64  `json:"start"`
+	Duration int64  `json:"duration"`
+	Spans    []Span `json:"spans"`
+}
+
+// Collector keeps the spans of the most recent traces. Once the configured number of traces is exceeded, the trace
+// that was reported first is dropped.
+type Collector struct {
+	mu     sync.RWMutex // protects traces and order
+	traces map[string][]Span
+	order  []string
+	size   int
+}
+
+// NewCollector creates a new instance of Collector that keeps up to the provided number of traces.
+func NewCollector(size int) *Collector {
+	return &Collector{
+		traces: make(map[string][]Span),
+		size:   max(size, 1),
+	}
+}
+
+// normalizeTraceID removes the upper half of 128-bit trace IDs that are zero, so that a trace is found regardless of
+// whether its ID is presented with 64 or 128 bits.
+func normalizeTraceID(traceID string) string {
+	traceID = strings.ToLower(traceID)
+	if len(traceID) == 32 && strings.Trim(traceID[:16], "0") == "" {
+		return traceID[16:]
+	}
+
+	return traceID
+}
+
+// Add stores the provided spans with the trace they belong to.
+func (c *Collector) Add(spans []Span) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, s := range spans {
+		if s.TraceID == "" || s.ID == "" {
+			continue
+		}
+
+		traceID := normalizeTraceID(s.TraceID)
+
+		if _, ok := c.traces[traceID]; !ok {
+			if len(c.order) == c.size {
+				delete(c.traces, c.order[0])
+				c.order = c.order[1:]
+			}
+
+			c.order = append(c.order, traceID)
+		}
+
+		if len(c.traces[traceID]) < maxSpansPerTrace {
+			c.traces[traceID] = append(c.traces[traceID], s)
+		}
+	}
+}
+
+// Trace assembles the trace with the provided ID.
+func (c *Collector) Trace(traceID string) (Trace, bool) {
+	c.mu.RLock()
+	spans, ok := c.traces[normalizeTraceID(traceID)]
+	spans = slices.Clone(spans)
+	c.mu.RUnlock()
+
+	if !ok {
+		return Trace{}, false
+	}
+
+	slices.SortStableFunc(spans, func(a, b Span) int {
+		if a.Timestamp < b.Timestamp {
+			return -1
+		} else if a.Timestamp > b.Timestamp {
+			return 1
+		}
+
+		return 0
+	})
+
+	t := Trace{TraceID: normalizeTraceID(traceID), Start: spans[0].Timestamp, Spans: spans}
+	for _, s := range spans {
+		t.Duration = max(t.Duration, s.Timestamp+s.Duration-t.Start)
+	}
+
+	return t, true
+}
+
+// TraceIDs returns the IDs of the kept traces, the most recently reported trace first.
+func (c *Collector) TraceIDs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	traceIDs := slices.Clone(c.order)
+	slices.Reverse(traceIDs)
+
+	return traceIDs
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(v)
+	logging.LogErr(err)
+}
+
+func (c *Collector) handleSpans(w http.ResponseWriter, r *http.Request) {
+	var spans []Span
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReportSize)).Decode(&spans); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	c.Add(spans)
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *Collector) handleTraceIDs(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, c.TraceIDs())
+}
+
+func (c *Collector) handleTrace(w http.ResponseWriter, r *http.Request) {
+	t, ok := c.Trace(r.PathValue("traceID"))
+	if !ok {
+		http.Error(w, "trace not found", http.StatusNotFound)
+
+		return
+	}
+
+	writeJSON(w, t)
+}
+
+// ReceiverHandler returns the handler that receives the spans reported by the Envoys.
+func (c *Collector) ReceiverHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+SpansPath, c.handleSpans)
+
+	return mux
+}
+
+// RegisterHandlers registers the read-only HTTP API that lists the kept traces and serves them by their ID.
+func (c *Collector) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /traces", c.handleTraceIDs)
+	mux.HandleFunc("GET /traces/{traceID}", c.handleTrace)
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2025 MBition GmbH
+
+package tracing
+
+import (
+	"gotest.tools/v3/assert"
+	"testing"
+)
+
+func TestCollectorTrace(t *testing.T) {
+	c := NewCollector(2)
+
+	c.Add([]Span{
+		{TraceID: "0000000000000000463AC35C9F6413AD", ID: "b", ParentID: "a", Timestamp: 1100, Duration: 500},
+		{TraceID: "463ac35c9f6413ad", ID: "a", Timestamp: 1000, Duration: 800},
+	})
+
+	// 128-bit and 64-bit representations refer to the same trace
+	trace, ok := c.Trace("0000000000000000463ac35c9f6413ad")
+	assert.Assert(t, ok)
+	assert.Equal(t, trace.Start, int64(1000))
+	assert.Equal(t, trace.Duration, int64(800))
+	assert.Equal(t, len(trace.Spans), 2)
+	assert.Equal(t, trace.Spans[0].ID, "a")
+
+	// the trace that was reported first is dropped once the collector is full
+	c.Add([]Span{{TraceID: "2", ID: "c"}, {TraceID: "3", ID: "d"}})
+	assert.DeepEqual(t, c.TraceIDs(), []string{"3", "2"})
+
+	_, ok = c.Trace("463ac35c9f6413ad")
+	assert.Assert(t, !ok)
+}
@@ -23,8 +23,19 @@ type BroadcastService struct {
 	p *net.PacketConn
 }
 
-// NewBroadcastService creates a new instance of BroadcastService.
+// NewBroadcastService creates a new instance of BroadcastService that receives the broadcast packets at the configured
+// UDP port.
 func NewBroadcastService(ctx context.Context, cfg *config.Config) (*BroadcastService, error) {
+	return newBroadcastService(ctx, cfg, cfg.UDPPort)
+}
+
+// NewBroadcastSender creates a new instance of BroadcastService bound to an ephemeral port, e.g., to send broadcast
+// packets from a host whose configured UDP port is already in use.
+func NewBroadcastSender(ctx context.Context, cfg *config.Config) (*BroadcastService, error) {
+	return newBroadcastService(ctx, cfg, 0)
+}
+
+func newBroadcastService(ctx context.Context, cfg *config.Config, port int) (*BroadcastService, error) {
 	lc := net.ListenConfig{}
 
 	// In debug mode we reuse address and port to ease up debugging
@@ -51,7 +62,7 @@ func NewBroadcastService(ctx context.Context, cfg *config.Config) (*BroadcastSer
 	packetConn, err := lc.ListenPacket(
 		ctx,
 		"udp4",
-		net.JoinHostPort("", strconv.Itoa(cfg.UDPPort)),
+		net.JoinHostPort("", strconv.Itoa(port)),
 	)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,7 @@ package udp
 import (
 	"bytes"
 	"encoding/gob"
+	"net"
 )
 
 // MessageType encodes the type of the CARISMA message.
@@ -52,14 +53,30 @@ func GetMessageType(msg Message) (MessageType, error) {
 	}
 }
 
-// BroadcastMessage encodes the hostname of the sender.
+// BroadcastMessage encodes the hostname of the sender and, if sent by the leading control plane, its leadership term.
 type BroadcastMessage struct {
 	Hostname string
+	Term     uint64
 }
 
 // NewBroadcastMessage creates a new broadcast message.
 func NewBroadcastMessage(hostname string) *BroadcastMessage {
-	return &BroadcastMessage{hostname}
+	return &BroadcastMessage{Hostname: hostname}
+}
+
+// NewLeaderMessage creates a new broadcast message that announces the leading control plane and its leadership term.
+func NewLeaderMessage(hostname string, term uint64) *BroadcastMessage {
+	return &BroadcastMessage{Hostname: hostname, Term: term}
+}
+
+// Host returns the hostname of the sender without port.
+func (g BroadcastMessage) Host() string {
+	host, _, err := net.SplitHostPort(g.Hostname)
+	if err != nil {
+		return g.Hostname
+	}
+
+	return host
 }
 
 // DecodeBroadcastMessage decodes the provided byte sequence into an instance of BroadcastMessage.
@@ -52,3 +52,25 @@ func TestMessageDecoding(t *testing.T) {
 		t.Fatal("the two ip addresses should be the same")
 	}
 }
+
+func TestLeaderMessageDecoding(t *testing.T) {
+	msg := NewLeaderMessage("carisma-standby:8016", 2)
+
+	b, err := msg.Bytes()
+	if err != nil {
+		t.Fatal("error occurred while encoding message")
+	}
+
+	msgDecoded, err := DecodeBroadcastMessage(b)
+	if err != nil {
+		t.Fatal("error occurred while decoding message")
+	}
+
+	if !reflect.DeepEqual(msg, msgDecoded) {
+		t.Fatal("the two leader messages should be the same")
+	}
+
+	if msgDecoded.Host() != "carisma-standby" {
+		t.Fatal("the host of the leader should not include the port")
+	}
+}